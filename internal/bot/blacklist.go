@@ -0,0 +1,119 @@
+// internal/bot/blacklist.go
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+func giveawayBlacklist(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0]
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(sub.Options))
+	for _, opt := range sub.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	switch sub.Name {
+	case "add":
+		target := getOption(optionMap, "user").UserValue(nil)
+		entry := &models.BlacklistEntry{
+			GuildID: i.GuildID,
+			UserID:  target.ID,
+			AddedBy: i.Member.User.ID,
+			AddedAt: time.Now(),
+		}
+		if reasonOpt := getOption(optionMap, "reason"); reasonOpt != nil {
+			entry.Reason = reasonOpt.StringValue()
+		}
+		if durationOpt := getOption(optionMap, "duration"); durationOpt != nil {
+			d, err := models.ParseAge(durationOpt.StringValue())
+			if err != nil || d <= 0 {
+				respondEphemeral(s, i, "Invalid duration. Use e.g. 7d, 2w or 12h.")
+				return
+			}
+			entry.ExpiresAt = entry.AddedAt.Add(d)
+		}
+		if err := db.AddBlacklistEntry(entry); err != nil {
+			respondEphemeral(s, i, "Could not save the blacklist entry, please try again.")
+			return
+		}
+
+		removed := removeFromGuildGiveaways(s, i.GuildID, target.ID)
+		content := fmt.Sprintf("<@%s> is blacklisted from giveaways %s.", target.ID, blacklistUntil(entry))
+		if removed > 0 {
+			content += fmt.Sprintf(" Removed them from %d running giveaway(s).", removed)
+		}
+		respondEphemeral(s, i, content)
+	case "remove":
+		target := getOption(optionMap, "user").UserValue(nil)
+		if !db.RemoveBlacklistEntry(i.GuildID, target.ID) {
+			respondEphemeral(s, i, fmt.Sprintf("<@%s> is not blacklisted.", target.ID))
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("<@%s> can enter giveaways again.", target.ID))
+	case "list":
+		entries := db.LoadBlacklist(i.GuildID)
+		if len(entries) == 0 {
+			respondEphemeral(s, i, "No users are blacklisted.")
+			return
+		}
+		var lines []string
+		for _, e := range entries {
+			line := fmt.Sprintf("<@%s> %s, by <@%s>", e.UserID, blacklistUntil(e), e.AddedBy)
+			if e.Reason != "" {
+				line += ": " + escapeMarkdown(e.Reason)
+			}
+			lines = append(lines, line)
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("Giveaway Blacklist (%d)", len(entries)),
+			Description: truncate(strings.Join(lines, "\n"), 4096),
+			Color:       0xff0000,
+		})
+	}
+}
+
+// removeFromGuildGiveaways takes a user out of every running giveaway of a
+// guild and returns how many they were removed from.
+func removeFromGuildGiveaways(s *discordgo.Session, guildID string, userID string) int {
+	models.GiveawaysMutex.Lock()
+	defer models.GiveawaysMutex.Unlock()
+
+	removed := 0
+	for _, ga := range models.Giveaways {
+		if ga.GuildID != guildID || ga.Status != models.StatusActive {
+			continue
+		}
+		if ga.RemoveParticipant(userID) {
+			removed++
+			models.UpdateGiveawayEmbed(s, ga)
+			db.SaveParticipants(ga)
+		}
+	}
+	return removed
+}
+
+func blacklistUntil(e *models.BlacklistEntry) string {
+	if e.ExpiresAt.IsZero() {
+		return "permanently"
+	}
+	return fmt.Sprintf("until <t:%d:f>", e.ExpiresAt.Unix())
+}
+
+func blacklistedMessage(e *models.BlacklistEntry) string {
+	msg := fmt.Sprintf("You are blacklisted from giveaways in this server %s.", blacklistUntil(e))
+	if e.Reason != "" {
+		msg += " Reason: " + escapeMarkdown(e.Reason)
+	}
+	return msg
+}
@@ -4,10 +4,16 @@ package bot
 import (
 	"log"
 
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/scheduler"
 	"github.com/bwmarrin/discordgo"
 )
 
-var session *discordgo.Session
+var (
+	session *discordgo.Session
+	sched   *scheduler.Scheduler
+)
 
 func SetSession(s *discordgo.Session) {
 	session = s
@@ -17,37 +23,250 @@ func GetSession() *discordgo.Session {
 	return session
 }
 
+// SetScheduler sets the scheduler that owns every timed giveaway event.
+func SetScheduler(s *scheduler.Scheduler) {
+	sched = s
+}
+
+func endKey(giveawayID string) scheduler.Key {
+	return scheduler.Key{GiveawayID: giveawayID, Kind: scheduler.KindEnd}
+}
+
+// ScheduleEnd queues the draw of a giveaway for its end time, along with the
+// reminders before it.
+func ScheduleEnd(ga *models.Giveaway) {
+	sched.Schedule(endKey(ga.ID), ga.EndTime, func() {
+		EndGiveaway(ga)
+	})
+	ScheduleReminders(ga)
+}
+
+// EndGiveaway draws a giveaway and records the result, keeping the giveaway
+// and its winners in the database so they can be rerolled later. It returns
+// false if the giveaway had already ended, e.g. when /end-giveaway races the
+// timer.
+func EndGiveaway(ga *models.Giveaway) bool {
+	models.GiveawaysMutex.Lock()
+	if ga.Status != models.StatusActive {
+		models.GiveawaysMutex.Unlock()
+		return false
+	}
+	ga.Status = models.StatusEnded
+	ga.Paused = false
+	sched.CancelGiveaway(ga.ID)
+	models.GiveawaysMutex.Unlock()
+
+	if ga.Drop {
+		closeDrop(GetSession(), ga)
+		return true
+	}
+	ensureSeed(ga)
+	winners, announcementID := models.EndGiveaway(GetSession(), ga)
+	db.SetGiveawayStatus(ga.ID, ga.GuildID, models.StatusEnded)
+	db.SaveParticipants(ga)
+	deadline := ga.ClaimDeadline(ga.EndedAt)
+	db.SaveWinners(ga.ID, ga.GuildID, winners, models.WinSourceDraw, deadline)
+	if announcementID != "" {
+		db.SetWinnerMessage(ga.ID, ga.GuildID, winners, announcementID)
+	}
+	if !deadline.IsZero() && announcementID != "" {
+		for _, uid := range winners {
+			ScheduleClaim(models.Win{GiveawayID: ga.ID, GuildID: ga.GuildID, UserID: uid, MessageID: announcementID, ClaimDeadline: deadline})
+		}
+	}
+	// Nothing was announced when the giveaway message is gone.
+	if len(winners) > 0 || len(ga.Participants) == 0 {
+		notifyEnded(GetSession(), ga, winners, announcementID)
+	}
+	return true
+}
+
+// ensureSeed gives a giveaway created before seeded draws a seed to draw with.
+// No commitment to that seed was ever published, so its draws are marked as
+// unverifiable.
+func ensureSeed(ga *models.Giveaway) {
+	if ga.Seed != "" {
+		return
+	}
+	ga.Seed = models.NewSeed()
+	ga.LateSeed = true
+	db.SetGiveawaySeed(ga.ID, ga.GuildID, ga.Seed)
+}
+
+// CloseExpiredGiveaway cancels a giveaway that ended too long ago while the
+// bot was offline to still be drawn, and closes its message.
+func CloseExpiredGiveaway(ga *models.Giveaway) {
+	ga.Status = models.StatusCancelled
+	db.SetGiveawayStatus(ga.ID, ga.GuildID, models.StatusCancelled)
+	models.CancelGiveaway(GetSession(), ga, "It ended while the bot was offline for too long, so no winners were drawn.")
+}
+
+// giveawayOptions are the options of /create-giveaway. Templates offer the
+// same options, see templateOptions.
+func giveawayOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "title",
+			Description: "Title of the giveaway",
+			Required:    true,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "end",
+			Description: "End time: duration (e.g., 1h30m) or date/time (YYYY-MM-DD [HH:MM])",
+			Required:    true,
+		},
+		{
+			Type:         discordgo.ApplicationCommandOptionChannel,
+			Name:         "channel",
+			Description:  "Channel to post the giveaway in (optional, defaults to this channel)",
+			Required:     false,
+			ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "ping-role",
+			Description: "Role to ping when the giveaway is posted (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "start",
+			Description: "Post later: duration (e.g., 2h) or date/time (YYYY-MM-DD [HH:MM]) (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "role",
+			Description: "Role required to join (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "roles",
+			Description: "More required roles, e.g. @Member @Verified (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "role-mode",
+			Description: "Whether members need any or all of the required roles (optional, default any)",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Any of the roles", Value: models.RoleModeAny},
+				{Name: "All of the roles", Value: models.RoleModeAll},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "blocked-roles",
+			Description: "Roles that can't join, e.g. @Muted (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "winners",
+			Description: "Number of winners (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "bonus-entries",
+			Description: "Role entry multipliers, e.g. @Booster=3 @Patron=2 (optional, defaults to server config)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "min-account-age",
+			Description: "Minimum Discord account age to join, e.g. 30d or 2w (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "min-member-age",
+			Description: "Minimum time in this server to join, e.g. 7d or 12h (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "reminders",
+			Description: "When to remind before the end, e.g. 1h, 10m or none (optional, defaults to server config)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "claim-window",
+			Description: "Time winners have to claim, e.g. 24h; unclaimed prizes are rerolled (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "drop",
+			Description: "Drop: the first members to press 🎉 win instantly instead of a draw (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "quiz-question",
+			Description: "Question members must answer correctly to enter (optional)",
+			Required:    false,
+			MaxLength:   maxQuizQuestion,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "quiz-answers",
+			Description: "Accepted answers to the quiz question, separated by | (required with a question)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "quiz-ignore-case",
+			Description: "Accept quiz answers regardless of upper/lower case (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "quiz-attempts",
+			Description: "How many answers each member may give (optional, defaults to 3)",
+			Required:    false,
+			MinValue:    ptrFloat(1),
+			MaxValue:    maxQuizAttempts,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "description",
+			Description: "Longer description of the prize (optional)",
+			Required:    false,
+			MaxLength:   maxPrizeDescription,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "image",
+			Description: "Image URL shown in the giveaway (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionAttachment,
+			Name:        "image-file",
+			Description: "Image upload shown in the giveaway, instead of an image URL (optional)",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "thumbnail",
+			Description: "Thumbnail URL shown in the corner of the giveaway (optional)",
+			Required:    false,
+		},
+	}
+}
+
 func GetCommands() []*discordgo.ApplicationCommand {
 	return []*discordgo.ApplicationCommand{
 		{
 			Name:        "create-giveaway",
 			Description: "Create a new giveaway",
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "title",
-					Description: "Title of the giveaway",
-					Required:    true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionString,
-					Name:        "end",
-					Description: "End time: duration (e.g., 1h30m) or date/time (YYYY-MM-DD [HH:MM])",
-					Required:    true,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionRole,
-					Name:        "role",
-					Description: "Role required to join (optional)",
-					Required:    false,
-				},
-				{
-					Type:        discordgo.ApplicationCommandOptionInteger,
-					Name:        "winners",
-					Description: "Number of winners (optional)",
-					Required:    false,
-				},
-			},
+			Options:     giveawayOptions(),
 		},
 		{
 			Name:        "list-giveaways",
@@ -99,6 +318,478 @@ func GetCommands() []*discordgo.ApplicationCommand {
 				},
 			},
 		},
+		{
+			Name:        "unremove",
+			Description: "Let a removed user enter a giveaway again (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "User to allow back in",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "end-giveaway",
+			Description: "End a giveaway now and draw its winners (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "pause-giveaway",
+			Description: "Pause a running giveaway and close entries (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "resume-giveaway",
+			Description: "Resume a paused giveaway (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "cancel-giveaway",
+			Description: "Cancel a giveaway without drawing winners (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "notify",
+					Description: "DM participants that the giveaway was cancelled (optional)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "edit-giveaway",
+			Description: "Change a running giveaway (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "title",
+					Description: "New title (optional)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "end",
+					Description: "New end time: duration from now (e.g., 1h30m) or date/time (YYYY-MM-DD [HH:MM])",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "winners",
+					Description: "New number of winners (optional)",
+					Required:    false,
+					MinValue:    ptrFloat(1),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "New role required to join (optional)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "remove-role",
+					Description: "Let everyone join by removing the required role (optional)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "roles",
+					Description: "Add required roles, e.g. @Member @Verified (optional)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "role-mode",
+					Description: "Whether members need any or all of the required roles (optional, default any)",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Any of the roles", Value: models.RoleModeAny},
+						{Name: "All of the roles", Value: models.RoleModeAll},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "blocked-roles",
+					Description: "Replace the roles that can't join, e.g. @Muted, or none to clear (optional)",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "reminders",
+					Description: "Replace when to remind before the end, e.g. 1h, 10m or none (optional)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "verify-giveaway",
+			Description: "Recompute the winners of a giveaway from its revealed seed",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "quiz-answers",
+			Description: "Review the answers given to a quiz giveaway (Admin/Mod/Host only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "id",
+					Description: "Giveaway ID (from /list-giveaways)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "user",
+					Description: "Only show the answers of this user (optional)",
+					Required:    false,
+				},
+			},
+		},
+		{
+			Name:        "scheduled-giveaways",
+			Description: "Manage giveaways waiting for their start time (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "List scheduled giveaways",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "cancel",
+					Description: "Cancel a scheduled giveaway before it is posted",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "id",
+							Description: "ID of the scheduled giveaway, see /scheduled-giveaways list",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "giveaway-series",
+			Description: "Recurring giveaways posted automatically (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "create",
+					Description: "Post a giveaway in this channel on a schedule",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "title",
+							Description: "Title of each giveaway, {n} is the occurrence number and {date} the date",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "schedule",
+							Description: "daily, weekly or a cron expression in UTC, e.g. 0 18 * * 5 for Fridays 18:00",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "duration",
+							Description: "How long each giveaway runs, e.g. 1h30m or 2d",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "winners",
+							Description: "Number of winners (optional)",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionRole,
+							Name:        "role",
+							Description: "Role required to join (optional)",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "first",
+							Description: "First giveaway: duration (e.g., 2h) or date/time (YYYY-MM-DD [HH:MM]) (optional)",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "List recurring giveaways",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "history",
+					Description: "Show the giveaways a recurring giveaway has posted",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "id",
+							Description: "ID of the recurring giveaway, see /giveaway-series list",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "stop",
+					Description: "Stop posting a recurring giveaway",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "id",
+							Description: "ID of the recurring giveaway, see /giveaway-series list",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "giveaway-template",
+			Description: "Reusable /create-giveaway options for this server (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "save",
+					Description: "Save giveaway options as a template, replacing one with the same name",
+					Options:     templateOptions("Name of the template", true),
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "List saved templates",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "delete",
+					Description: "Delete a template",
+					Options: []*discordgo.ApplicationCommandOption{
+						templateNameOption("Name of the template to delete"),
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "use",
+					Description: "Create a giveaway from a template; options given here override it",
+					Options:     templateOptions("Name of the template to use", false),
+				},
+			},
+		},
+		{
+			Name:        "giveaway-blacklist",
+			Description: "Keep users out of all giveaways in this server (Admin/Mod only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "add",
+					Description: "Blacklist a user and remove them from running giveaways",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "User to blacklist",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "reason",
+							Description: "Why the user is blacklisted (optional)",
+							Required:    false,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "duration",
+							Description: "How long the blacklist lasts, e.g. 7d or 12h (optional, default forever)",
+							Required:    false,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "remove",
+					Description: "Lift a user's blacklist",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionUser,
+							Name:        "user",
+							Description: "User to remove from the blacklist",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "list",
+					Description: "List blacklisted users",
+				},
+			},
+		},
+		{
+			Name:        "giveaway-config",
+			Description: "Configure the giveaway bot for this server (Admin only)",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "manager-roles",
+					Description: "Roles allowed to manage giveaways",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "add",
+							Description: "Allow a role to manage giveaways",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role to allow",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "remove",
+							Description: "Stop a role from managing giveaways",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role to remove",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "List roles allowed to manage giveaways",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "reminders",
+					Description: "Default reminders before giveaways end",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set",
+							Description: "Set the reminders of new giveaways",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionString,
+									Name:        "offsets",
+									Description: "Time before the end, e.g. 1h, 10m, or none to turn reminders off",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "show",
+							Description: "Show the reminders of new giveaways",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+					Name:        "bonus-entries",
+					Description: "Default extra entries for members with a role",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "set",
+							Description: "Give members with a role more entries in new giveaways",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role that gets bonus entries",
+									Required:    true,
+								},
+								{
+									Type:        discordgo.ApplicationCommandOptionInteger,
+									Name:        "entries",
+									Description: "Total entries for members with this role",
+									Required:    true,
+									MinValue:    ptrFloat(2),
+									MaxValue:    maxBonusEntries,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "remove",
+							Description: "Remove the bonus entries of a role",
+							Options: []*discordgo.ApplicationCommandOption{
+								{
+									Type:        discordgo.ApplicationCommandOptionRole,
+									Name:        "role",
+									Description: "Role to remove",
+									Required:    true,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+							Name:        "list",
+							Description: "List roles with bonus entries",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -0,0 +1,138 @@
+// internal/bot/claims.go
+package bot
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/scheduler"
+	"github.com/bwmarrin/discordgo"
+)
+
+func claimKey(giveawayID string, userID string) scheduler.Key {
+	return scheduler.Key{GiveawayID: giveawayID, Kind: scheduler.KindClaim, Tag: userID}
+}
+
+// ScheduleClaim queues the reroll of a win that is not claimed by its
+// deadline.
+func ScheduleClaim(w models.Win) {
+	sched.Schedule(claimKey(w.GiveawayID, w.UserID), w.ClaimDeadline, func() {
+		expireClaim(w.GiveawayID, w.GuildID, w.UserID)
+	})
+}
+
+// ScheduleClaimDeadlines queues every win still waiting to be claimed. Wins
+// whose deadline passed while the bot was offline are rerolled as soon as the
+// scheduler starts.
+func ScheduleClaimDeadlines() {
+	for _, w := range db.LoadPendingClaims() {
+		ScheduleClaim(w)
+	}
+}
+
+func handleClaim(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+	userID := i.Member.User.ID
+	win, ok := db.GetWin(ga.ID, ga.GuildID, userID)
+	if !ok {
+		respondEphemeral(s, i, "Only winners of this giveaway can claim it.")
+		return
+	}
+	if !win.ClaimedAt.IsZero() {
+		respondEphemeral(s, i, "You already claimed your prize.")
+		return
+	}
+	if win.Forfeited || !db.ClaimWin(ga.ID, ga.GuildID, userID) {
+		respondEphemeral(s, i, "Your claim window has passed and the prize was rerolled.")
+		return
+	}
+	sched.Cancel(claimKey(ga.ID, userID))
+	respondEphemeral(s, i, fmt.Sprintf("🎁 You claimed your prize for **%s**!", ga.Title))
+	refreshClaimStatus(s, ga, win.MessageID)
+}
+
+// expireClaim forfeits a win that was not claimed in time and rerolls it.
+func expireClaim(giveawayID string, guildID string, userID string) {
+	win, ok := db.GetWin(giveawayID, guildID, userID)
+	if !ok || !db.ForfeitWin(giveawayID, guildID, userID) {
+		return
+	}
+	ga, ok := findGiveaway(giveawayID, guildID)
+	if !ok {
+		return
+	}
+	s := GetSession()
+	refreshClaimStatus(s, ga, win.MessageID)
+	if ga.Status == models.StatusCancelled {
+		return
+	}
+
+	note := fmt.Sprintf("<@%s> did not claim their prize in time.", userID)
+	rerolled, round, ok := rerollWinner(ga)
+	if !ok {
+		_, err := s.ChannelMessageSend(ga.ChannelID, fmt.Sprintf("%s There is no one left to reroll the giveaway for **%s**.", note, ga.Title))
+		if err != nil {
+			log.Println("Error sending claim expiry message:", err)
+		}
+		return
+	}
+	announceReroll(s, ga, rerolled, round, note)
+}
+
+// refreshClaimStatus updates the Claims field of a winner announcement and
+// disables its Claim button once no one there can claim anymore.
+func refreshClaimStatus(s *discordgo.Session, ga *models.Giveaway, messageID string) {
+	if messageID == "" {
+		return
+	}
+	wins := db.LoadAnnouncementWins(ga.ID, ga.GuildID, messageID)
+	if len(wins) == 0 {
+		return
+	}
+	msg, err := s.ChannelMessage(ga.ChannelID, messageID)
+	if err != nil {
+		log.Printf("Error fetching winner message %s in channel %s: %v", messageID, ga.ChannelID, err)
+		return
+	}
+	if len(msg.Embeds) == 0 {
+		return
+	}
+
+	embed := msg.Embeds[0]
+	field := models.ClaimsField(wins)
+	replaced := false
+	for k, f := range embed.Fields {
+		if f.Name == models.ClaimsFieldName {
+			embed.Fields[k] = field
+			replaced = true
+		}
+	}
+	if !replaced {
+		embed.Fields = append([]*discordgo.MessageEmbedField{field}, embed.Fields...)
+	}
+	claimOpen := false
+	for _, w := range wins {
+		if w.Pending() {
+			claimOpen = true
+		}
+	}
+	models.GiveawaysMutex.RLock()
+	components := models.WinnerComponents(ga, claimOpen)
+	models.GiveawaysMutex.RUnlock()
+
+	_, err = s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         messageID,
+		Channel:    ga.ChannelID,
+		Embed:      embed,
+		Components: &components,
+	})
+	if err != nil {
+		log.Printf("Error updating winner message %s in channel %s: %v", messageID, ga.ChannelID, err)
+	}
+}
@@ -0,0 +1,29 @@
+package bot
+
+import "testing"
+
+// Discord rejects the whole command list if any command or subcommand has too
+// many options, which would leave the bot without commands.
+func TestCommandOptionLimits(t *testing.T) {
+	for _, cmd := range GetCommands() {
+		if len(cmd.Options) > maxCommandOptions {
+			t.Errorf("/%s has %d options, want at most %d", cmd.Name, len(cmd.Options), maxCommandOptions)
+		}
+		for _, sub := range cmd.Options {
+			if len(sub.Options) > maxCommandOptions {
+				t.Errorf("/%s %s has %d options, want at most %d", cmd.Name, sub.Name, len(sub.Options), maxCommandOptions)
+			}
+		}
+	}
+}
+
+func TestTemplateSaveOptions(t *testing.T) {
+	for _, opt := range templateOptions("name", true) {
+		if opt.Name == "start" || opt.Name == "image-file" {
+			t.Errorf("template save offers the %s option", opt.Name)
+		}
+		if opt.Name != "name" && opt.Required {
+			t.Errorf("template save requires the %s option", opt.Name)
+		}
+	}
+}
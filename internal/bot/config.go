@@ -0,0 +1,188 @@
+// internal/bot/config.go
+package bot
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+func isAdmin(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&discordgo.PermissionAdministrator != 0
+}
+
+func giveawayConfig(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !isAdmin(i) {
+		respondEphemeral(s, i, "Only administrators can change the giveaway configuration.")
+		return
+	}
+
+	group := i.ApplicationCommandData().Options[0]
+	switch group.Name {
+	case "manager-roles":
+		configManagerRoles(s, i, group.Options[0])
+	case "bonus-entries":
+		configBonusEntries(s, i, group.Options[0])
+	case "reminders":
+		configReminders(s, i, group.Options[0])
+	}
+}
+
+func configManagerRoles(s *discordgo.Session, i *discordgo.InteractionCreate, sub *discordgo.ApplicationCommandInteractionDataOption) {
+	roles := db.GetManagerRoles(i.GuildID)
+
+	switch sub.Name {
+	case "add":
+		roleID := sub.Options[0].RoleValue(nil, "").ID
+		if slices.Contains(roles, roleID) {
+			respondEphemeral(s, i, fmt.Sprintf("<@&%s> can already manage giveaways.", roleID))
+			return
+		}
+		if err := db.SetManagerRoles(i.GuildID, append(roles, roleID)); err != nil {
+			respondEphemeral(s, i, "Could not save the manager roles, please try again.")
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("<@&%s> can now manage giveaways.", roleID))
+	case "remove":
+		roleID := sub.Options[0].RoleValue(nil, "").ID
+		idx := slices.Index(roles, roleID)
+		if idx < 0 {
+			respondEphemeral(s, i, fmt.Sprintf("<@&%s> is not a manager role.", roleID))
+			return
+		}
+		if err := db.SetManagerRoles(i.GuildID, slices.Delete(roles, idx, idx+1)); err != nil {
+			respondEphemeral(s, i, "Could not save the manager roles, please try again.")
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("<@&%s> can no longer manage giveaways.", roleID))
+	case "list":
+		if len(roles) == 0 {
+			respondEphemeral(s, i, "No manager roles configured. Only administrators can manage giveaways.")
+			return
+		}
+		var mentions []string
+		for _, r := range roles {
+			mentions = append(mentions, "<@&"+r+">")
+		}
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{{
+					Title:       "Manager Roles",
+					Description: strings.Join(mentions, "\n"),
+					Color:       0x00ff00,
+				}},
+				Flags: discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+}
+
+// Highest number of entries a bonus role can grant.
+const maxBonusEntries = 100
+
+func configBonusEntries(s *discordgo.Session, i *discordgo.InteractionCreate, sub *discordgo.ApplicationCommandInteractionDataOption) {
+	weights := db.GetBonusRoles(i.GuildID)
+
+	switch sub.Name {
+	case "set":
+		roleID := sub.Options[0].RoleValue(nil, "").ID
+		entries := int(sub.Options[1].IntValue())
+		weights[roleID] = entries
+		if err := db.SetBonusRoles(i.GuildID, weights); err != nil {
+			respondEphemeral(s, i, "Could not save the bonus entries, please try again.")
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("Members with <@&%s> now get **%d** entries in new giveaways.", roleID, entries))
+	case "remove":
+		roleID := sub.Options[0].RoleValue(nil, "").ID
+		if _, ok := weights[roleID]; !ok {
+			respondEphemeral(s, i, fmt.Sprintf("<@&%s> has no bonus entries.", roleID))
+			return
+		}
+		delete(weights, roleID)
+		if err := db.SetBonusRoles(i.GuildID, weights); err != nil {
+			respondEphemeral(s, i, "Could not save the bonus entries, please try again.")
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("<@&%s> no longer gets bonus entries.", roleID))
+	case "list":
+		if len(weights) == 0 {
+			respondEphemeral(s, i, "No bonus entry roles configured. Everyone gets one entry.")
+			return
+		}
+		roles := make([]string, 0, len(weights))
+		for r := range weights {
+			roles = append(roles, r)
+		}
+		sort.Strings(roles)
+		var lines []string
+		for _, r := range roles {
+			lines = append(lines, fmt.Sprintf("<@&%s>: **%d** entries", r, weights[r]))
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       "Bonus Entries",
+			Description: strings.Join(lines, "\n"),
+			Color:       0x00ff00,
+		})
+	}
+}
+
+func configReminders(s *discordgo.Session, i *discordgo.InteractionCreate, sub *discordgo.ApplicationCommandInteractionDataOption) {
+	switch sub.Name {
+	case "set":
+		offsets, err := models.ParseReminders(sub.Options[0].StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid reminders: "+err.Error())
+			return
+		}
+		if err := db.SetReminders(i.GuildID, offsets); err != nil {
+			respondEphemeral(s, i, "Could not save the reminders, please try again.")
+			return
+		}
+		if len(offsets) == 0 {
+			respondEphemeral(s, i, "New giveaways have no reminders.")
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("New giveaways remind **%s** before they end.", models.FormatReminders(offsets)))
+	case "show":
+		offsets := db.GetReminders(i.GuildID)
+		if len(offsets) == 0 {
+			respondEphemeral(s, i, "No default reminders configured.")
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("New giveaways remind **%s** before they end.", models.FormatReminders(offsets)))
+	}
+}
+
+// User mentions are matched too, so they can be rejected rather than taken
+// for a role.
+var bonusEntryPattern = regexp.MustCompile(`(<@[!&]?)?(\d{15,21})>?\s*[=:x×]\s*(\d+)`)
+
+// parseBonusEntries reads role multipliers like "<@&123>=3, <@&456>=2" from
+// the create-giveaway option. User mentions are rejected.
+func parseBonusEntries(input string) (map[string]int, error) {
+	weights := make(map[string]int)
+	matches := bonusEntryPattern.FindAllStringSubmatch(input, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("expected role=entries pairs, e.g. @Booster=3 @Patron=2")
+	}
+	for _, m := range matches {
+		if m[1] == "<@" || m[1] == "<@!" {
+			return nil, fmt.Errorf("<@%s> is a user, not a role", m[2])
+		}
+		n, err := strconv.Atoi(m[3])
+		if err != nil || n < 1 || n > maxBonusEntries {
+			return nil, fmt.Errorf("entries for <@&%s> must be between 1 and %d", m[2], maxBonusEntries)
+		}
+		weights[m[2]] = n
+	}
+	return weights, nil
+}
@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseBonusEntries(t *testing.T) {
+	const booster, patron = "123456789012345678", "876543210987654321"
+	tests := []struct {
+		input   string
+		want    map[string]int
+		wantErr bool
+	}{
+		{input: "<@&123456789012345678>=3", want: map[string]int{booster: 3}},
+		{input: "<@&123456789012345678>=3, <@&876543210987654321>=2", want: map[string]int{booster: 3, patron: 2}},
+		{input: "<@&123456789012345678> x 3 <@&876543210987654321>: 2", want: map[string]int{booster: 3, patron: 2}},
+		{input: "123456789012345678×4", want: map[string]int{booster: 4}},
+		{input: "<@&123456789012345678>=2 <@&123456789012345678>=5", want: map[string]int{booster: 5}},
+		{input: "<@&123456789012345678>=100", want: map[string]int{booster: 100}},
+		{input: "", wantErr: true},
+		{input: "booster=3", wantErr: true},
+		{input: "<@&123456789012345678>", wantErr: true},
+		{input: "<@&123456789012345678>=0", wantErr: true},
+		{input: "<@&123456789012345678>=101", wantErr: true},
+		{input: "<@123456789012345678>=3", wantErr: true},
+		{input: "<@!123456789012345678>=3", wantErr: true},
+		{input: "<@&876543210987654321>=2 <@123456789012345678>=3", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseBonusEntries(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseBonusEntries(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseBonusEntries(%q): %v", tt.input, err)
+			continue
+		}
+		if !maps.Equal(got, tt.want) {
+			t.Errorf("parseBonusEntries(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
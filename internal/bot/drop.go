@@ -0,0 +1,76 @@
+// internal/bot/drop.go
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// claimDropSlot gives an eligible member the next free slot of a drop. Slots
+// are taken under GiveawaysMutex, so concurrent clicks never hand out more
+// slots than the drop has winners.
+func claimDropSlot(s *discordgo.Session, i *discordgo.InteractionCreate, ga *models.Giveaway, userID string) {
+	models.GiveawaysMutex.Lock()
+	if ga.Status != models.StatusActive || ga.Paused {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, "This drop is not running.")
+		return
+	}
+	if slot := slices.Index(ga.Excluded, userID); slot >= 0 {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, fmt.Sprintf("You already claimed slot %d of this drop.", slot+1))
+		return
+	}
+	if ga.DropSlotsLeft() == 0 {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, "Too late, every slot of this drop has been claimed.")
+		return
+	}
+	ga.Excluded = append(ga.Excluded, userID)
+	ga.Participants = append(ga.Participants, userID)
+	if ga.Entries == nil {
+		ga.Entries = map[string]int{}
+	}
+	ga.Entries[userID] = 1
+	slot := len(ga.Excluded)
+	exhausted := ga.DropSlotsLeft() == 0
+	db.SaveWinners(ga.ID, ga.GuildID, []string{userID}, models.WinSourceDrop, time.Time{})
+	db.SaveParticipants(ga)
+	models.GiveawaysMutex.Unlock()
+
+	respondEphemeral(s, i, fmt.Sprintf("🎉 You claimed slot **%d** of %d and won **%s**!", slot, ga.Winners, escapeMarkdown(ga.Title)))
+	if failed := notifyWinners(s, ga, []string{userID}, ""); len(failed) > 0 {
+		reportDMFailures(s, ga, failed, true)
+	}
+
+	if exhausted {
+		EndGiveaway(ga)
+		return
+	}
+	models.GiveawaysMutex.Lock()
+	if ga.Status == models.StatusActive {
+		models.UpdateGiveawayEmbed(s, ga)
+	}
+	models.GiveawaysMutex.Unlock()
+}
+
+// closeDrop finishes a drop that ran out of slots or time. Its winners were
+// recorded as they claimed, so there is nothing to draw.
+func closeDrop(s *discordgo.Session, ga *models.Giveaway) {
+	ga.EndedAt = time.Now()
+	models.CloseDrop(s, ga)
+	db.SetGiveawayStatus(ga.ID, ga.GuildID, models.StatusEnded)
+
+	summary := fmt.Sprintf("Your drop **%s** has ended with %d of %d slots claimed.", escapeMarkdown(ga.Title), len(ga.Excluded), ga.Winners)
+	if len(ga.Excluded) > 0 {
+		summary += "\nWinners: " + mentions(ga.Excluded)
+	}
+	if !notifyHost(s, ga, summary+"\n"+messageLink(ga, "")) {
+		reportDMFailures(s, ga, nil, false)
+	}
+}
@@ -4,11 +4,13 @@ package bot
 import (
 	"fmt"
 	"log"
-	"math/rand"
+	"net/url"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
 	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
@@ -31,6 +33,38 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	switch data.Name {
 	case "create-giveaway":
 		createGiveaway(s, i)
+	case "giveaway-config":
+		giveawayConfig(s, i)
+	case "giveaway-blacklist":
+		giveawayBlacklist(s, i)
+	case "end-giveaway":
+		endGiveawayCommand(s, i, data.Options[0].StringValue())
+	case "edit-giveaway":
+		editGiveaway(s, i)
+	case "pause-giveaway":
+		pauseGiveaway(s, i, data.Options[0].StringValue())
+	case "resume-giveaway":
+		resumeGiveaway(s, i, data.Options[0].StringValue())
+	case "verify-giveaway":
+		verifyGiveaway(s, i, data.Options[0].StringValue())
+	case "cancel-giveaway":
+		notify := false
+		if len(data.Options) > 1 {
+			notify = data.Options[1].BoolValue()
+		}
+		cancelGiveawayCommand(s, i, data.Options[0].StringValue(), notify)
+	case "quiz-answers":
+		userID := ""
+		if len(data.Options) > 1 {
+			userID = data.Options[1].UserValue(nil).ID
+		}
+		quizAnswers(s, i, data.Options[0].StringValue(), userID)
+	case "scheduled-giveaways":
+		scheduledGiveaways(s, i)
+	case "giveaway-series":
+		giveawaySeries(s, i)
+	case "giveaway-template":
+		giveawayTemplate(s, i)
 	case "list-giveaways":
 		userID := ""
 		if len(data.Options) > 0 && data.Options[0].Name == "user" {
@@ -50,7 +84,7 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 		models.GiveawaysMutex.Lock()
 		ga, exists := models.Giveaways[giveawayID]
-		if !exists {
+		if !exists || ga.Status != models.StatusActive {
 			models.GiveawaysMutex.Unlock()
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -63,14 +97,7 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		}
 
 		// Remove user from participants
-		removed := false
-		for idx, p := range ga.Participants {
-			if p == userID {
-				ga.Participants = append(ga.Participants[:idx], ga.Participants[idx+1:]...)
-				removed = true
-				break
-			}
-		}
+		removed := ga.RemoveParticipant(userID)
 
 		if !removed {
 			models.GiveawaysMutex.Unlock()
@@ -86,7 +113,7 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 		// Update embed + DB
 		models.UpdateGiveawayEmbed(s, ga)
-		db.SaveParticipants(ga.ID, ga.GuildID, ga.Participants)
+		db.SaveParticipants(ga)
 		models.GiveawaysMutex.Unlock()
 
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -108,24 +135,16 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			if ga.GuildID != i.GuildID {
 				continue
 			}
-			if time.Now().After(ga.EndTime) {
+			if ga.Status != models.StatusActive || !ga.Paused && time.Now().After(ga.EndTime) {
 				continue
 			}
 
-			removed := false
-			for idx, p := range ga.Participants {
-				if p == userID {
-					ga.Participants = append(ga.Participants[:idx], ga.Participants[idx+1:]...)
-					removed = true
-					break
-				}
-			}
-			if removed {
+			if ga.RemoveParticipant(userID) {
 				leftCount++
 				leftTitles = append(leftTitles, escapeMarkdown(ga.Title))
 
 				models.UpdateGiveawayEmbed(s, ga)
-				db.SaveParticipants(ga.ID, ga.GuildID, ga.Participants)
+				db.SaveParticipants(ga)
 			}
 			guildID := i.GuildID
 			if guildID == "" {
@@ -136,6 +155,9 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 			loc, _ := time.LoadLocation("Etc/UTC")
 			timeLeft := fmt.Sprintf("<t:%d:R>", ga.EndTime.In(loc).Unix())
+			if ga.Paused {
+				timeLeft = fmt.Sprintf("paused, %s left", ga.Remaining.Round(time.Second))
+			}
 
 			field := &discordgo.MessageEmbedField{
 				Name:   fmt.Sprintf("%s (ID: `%s`)", titleLink, ga.MessageID),
@@ -191,7 +213,7 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 		models.GiveawaysMutex.Lock()
 		ga, exists := models.Giveaways[giveawayID]
-		if !exists || ga.GuildID != i.GuildID {
+		if !exists || ga.GuildID != i.GuildID || ga.Status != models.StatusActive {
 			models.GiveawaysMutex.Unlock()
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -203,21 +225,18 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			return
 		}
 
-		removed := false
-		for idx, p := range ga.Participants {
-			if p == targetUser.ID {
-				ga.Participants = append(ga.Participants[:idx], ga.Participants[idx+1:]...)
-				removed = true
-				break
-			}
-		}
+		removed := ga.RemoveParticipant(targetUser.ID)
+
+		// Keep the user from clicking 🎉 again, even if they had not
+		// entered yet.
+		db.AddRemovedParticipant(ga.ID, ga.GuildID, targetUser.ID, actorID)
 
 		if !removed {
 			models.GiveawaysMutex.Unlock()
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf("<@%s> is not in this giveaway.", targetUser.ID),
+					Content: fmt.Sprintf("<@%s> is not in this giveaway, but can no longer enter it.", targetUser.ID),
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
@@ -226,20 +245,37 @@ func handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 		// Update embed + DB
 		models.UpdateGiveawayEmbed(s, ga)
-		db.SaveParticipants(ga.ID, ga.GuildID, ga.Participants)
+		db.SaveParticipants(ga)
 		models.GiveawaysMutex.Unlock()
 
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
 				Content: fmt.Sprintf(
-					"<@%s> has been removed from giveaway **%s** by <@%s>.",
+					"<@%s> has been removed from giveaway **%s** by <@%s>. Use /unremove to let them enter again.",
 					targetUser.ID, escapeMarkdown(ga.Title), actorID,
 				),
 				Flags: discordgo.MessageFlagsEphemeral,
 			},
 		})
+	case "unremove":
+		if !hasPermission(s, i) {
+			respondEphemeral(s, i, "You do not have permission to use this command.")
+			return
+		}
 
+		targetUser := data.Options[0].UserValue(nil)
+		giveawayID := data.Options[1].StringValue()
+		ga, ok := findGiveaway(giveawayID, i.GuildID)
+		if !ok {
+			respondEphemeral(s, i, "Giveaway not found.")
+			return
+		}
+		if !db.DeleteRemovedParticipant(ga.ID, ga.GuildID, targetUser.ID) {
+			respondEphemeral(s, i, fmt.Sprintf("<@%s> was not removed from this giveaway.", targetUser.ID))
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("<@%s> can enter giveaway **%s** again.", targetUser.ID, escapeMarkdown(ga.Title)))
 	}
 }
 
@@ -253,7 +289,7 @@ func hasPermission(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
 		return true
 	}
 
-	allowedRoles := []string{"1348095555594879026", "1172677966912815174", "1436059069424336958"}
+	allowedRoles := db.GetManagerRoles(i.GuildID)
 	for _, role := range member.Roles {
 		if slices.Contains(allowedRoles, role) {
 			return true
@@ -262,6 +298,15 @@ func hasPermission(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
 	return false
 }
 
+// canManage reports whether the user may end or reroll a giveaway: managers
+// can for every giveaway, hosts for their own.
+func canManage(s *discordgo.Session, i *discordgo.InteractionCreate, ga *models.Giveaway) bool {
+	if i.Member != nil && ga.HostID != "" && i.Member.User.ID == ga.HostID {
+		return true
+	}
+	return hasPermission(s, i)
+}
+
 func handleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	customID := i.MessageComponentData().CustomID
 	userID := i.Member.User.ID
@@ -269,6 +314,8 @@ func handleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 	if customID == "enter_giveaway" {
 		handleEnterGiveaway(s, i, userID, messageID)
+	} else if customID == "remind_me" {
+		handleRemindMe(s, i, userID, messageID)
 	} else if strings.HasPrefix(customID, "list_participants_") {
 		pageStr := strings.TrimPrefix(customID, "list_participants_")
 		page, _ := strconv.Atoi(pageStr)
@@ -283,19 +330,11 @@ func handleButtonClick(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		}
 		showParticipants(s, i, page, messageID)
 	} else if strings.HasPrefix(customID, "reroll_") {
-		if !hasPermission(s, i) {
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "You do not have permissions to reroll.",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				},
-			})
-			return
-		}
 		giveawayID := strings.TrimPrefix(customID, "reroll_")
 		handleReroll(s, i, giveawayID)
 		return
+	} else if strings.HasPrefix(customID, "claim_") {
+		handleClaim(s, i, strings.TrimPrefix(customID, "claim_"))
 	}
 }
 
@@ -306,6 +345,36 @@ func getOption(m map[string]*discordgo.ApplicationCommandInteractionDataOption,
 	return nil
 }
 
+// User mentions are matched too, so the ID inside one is not taken for a raw
+// role ID.
+var roleMentionPattern = regexp.MustCompile(`<@&(\d+)>|<@!?(\d+)>|\b(\d{15,21})\b`)
+
+// parseRoleMentions reads role mentions or raw role IDs from a string option.
+// User mentions are rejected.
+func parseRoleMentions(input string) ([]string, error) {
+	var roles []string
+	for _, m := range roleMentionPattern.FindAllStringSubmatch(input, -1) {
+		if m[2] != "" {
+			return nil, fmt.Errorf("<@%s> is a user, not a role", m[2])
+		}
+		id := m[1]
+		if id == "" {
+			id = m[3]
+		}
+		roles = appendUnique(roles, id)
+	}
+	return roles, nil
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func createGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	if !hasPermission(s, i) {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -322,12 +391,38 @@ func createGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	for _, opt := range options {
 		optionMap[opt.Name] = opt
 	}
-	var roleID string
+	createGiveawayFromOptions(s, i, optionMap)
+}
+
+// createGiveawayFromOptions creates a giveaway from /create-giveaway options,
+// which may also come from a template.
+func createGiveawayFromOptions(s *discordgo.Session, i *discordgo.InteractionCreate, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+	var requiredRoles, blockedRoles []string
+	roleMode := models.RoleModeAny
 	winners := 1 // default
 	title := getOption(optionMap, "title").StringValue()
 	endStr := getOption(optionMap, "end").StringValue()
 	if roleOpt := getOption(optionMap, "role"); roleOpt != nil {
-		roleID = roleOpt.RoleValue(nil, "").ID
+		requiredRoles = append(requiredRoles, roleOpt.RoleValue(nil, "").ID)
+	}
+	if rolesOpt := getOption(optionMap, "roles"); rolesOpt != nil {
+		roles, err := parseRoleMentions(rolesOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid roles: "+err.Error())
+			return
+		}
+		requiredRoles = appendUnique(requiredRoles, roles...)
+	}
+	if modeOpt := getOption(optionMap, "role-mode"); modeOpt != nil {
+		roleMode = modeOpt.StringValue()
+	}
+	if blockedOpt := getOption(optionMap, "blocked-roles"); blockedOpt != nil {
+		var err error
+		blockedRoles, err = parseRoleMentions(blockedOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid blocked roles: "+err.Error())
+			return
+		}
 	}
 
 	if winnerOpt := getOption(optionMap, "winners"); winnerOpt != nil {
@@ -337,7 +432,24 @@ func createGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		}
 	}
 
-	endTime, err := models.ParseEndTime(endStr)
+	// A scheduled giveaway counts an end duration from its start.
+	var startTime time.Time
+	from := time.Now()
+	if startOpt := getOption(optionMap, "start"); startOpt != nil {
+		var err error
+		startTime, err = models.ParseEndTime(startOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid start time format: "+err.Error())
+			return
+		}
+		if !startTime.After(time.Now()) {
+			respondEphemeral(s, i, "The start time must be in the future.")
+			return
+		}
+		from = startTime
+	}
+
+	endTime, err := models.ParseEndTimeFrom(endStr, from)
 	if err != nil {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -348,6 +460,186 @@ func createGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		})
 		return
 	}
+	if !startTime.IsZero() && !endTime.After(startTime) {
+		respondEphemeral(s, i, "The end time must be after the start time.")
+		return
+	}
+
+	var minAccountAge, minMemberAge time.Duration
+	if ageOpt := getOption(optionMap, "min-account-age"); ageOpt != nil {
+		minAccountAge, err = models.ParseAge(ageOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid minimum account age: "+err.Error()+". Use e.g. 30d, 2w or 12h.")
+			return
+		}
+	}
+	if ageOpt := getOption(optionMap, "min-member-age"); ageOpt != nil {
+		minMemberAge, err = models.ParseAge(ageOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid minimum member age: "+err.Error()+". Use e.g. 7d, 1w or 12h.")
+			return
+		}
+	}
+
+	var description, imageURL, thumbnailURL string
+	var imageUpload *discordgo.MessageAttachment
+	if descOpt := getOption(optionMap, "description"); descOpt != nil {
+		description = strings.TrimSpace(descOpt.StringValue())
+		if len(description) > maxPrizeDescription {
+			respondEphemeral(s, i, fmt.Sprintf("The description can be at most %d characters.", maxPrizeDescription))
+			return
+		}
+	}
+	if imageOpt := getOption(optionMap, "image"); imageOpt != nil {
+		imageURL, err = parseImageURL(imageOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid image: "+err.Error())
+			return
+		}
+	}
+	if fileOpt := getOption(optionMap, "image-file"); fileOpt != nil {
+		attachmentID, _ := fileOpt.Value.(string)
+		var attachment *discordgo.MessageAttachment
+		if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+			attachment = resolved.Attachments[attachmentID]
+		}
+		if attachment == nil || !strings.HasPrefix(attachment.ContentType, "image/") {
+			respondEphemeral(s, i, "The image file must be an image.")
+			return
+		}
+		if attachment.Size > models.MaxImageUpload {
+			respondEphemeral(s, i, fmt.Sprintf("The image file can be at most %d MB.", models.MaxImageUpload>>20))
+			return
+		}
+		imageUpload = attachment
+	}
+	if thumbOpt := getOption(optionMap, "thumbnail"); thumbOpt != nil {
+		thumbnailURL, err = parseImageURL(thumbOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid thumbnail: "+err.Error())
+			return
+		}
+	}
+
+	reminders := db.GetReminders(i.GuildID)
+	if reminderOpt := getOption(optionMap, "reminders"); reminderOpt != nil {
+		reminders, err = models.ParseReminders(reminderOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid reminders: "+err.Error())
+			return
+		}
+	}
+
+	var claimWindow time.Duration
+	if claimOpt := getOption(optionMap, "claim-window"); claimOpt != nil {
+		claimWindow, err = models.ParseAge(claimOpt.StringValue())
+		if err != nil || claimWindow <= 0 {
+			respondEphemeral(s, i, "Invalid claim window. Use e.g. 24h, 2d or 1w.")
+			return
+		}
+	}
+
+	var drop bool
+	if dropOpt := getOption(optionMap, "drop"); dropOpt != nil {
+		drop = dropOpt.BoolValue()
+	}
+	if drop && claimWindow > 0 {
+		respondEphemeral(s, i, "Drops can't have a claim window, their winners are decided the moment they press 🎉.")
+		return
+	}
+
+	var question string
+	var answers []string
+	if questionOpt := getOption(optionMap, "quiz-question"); questionOpt != nil {
+		question = strings.TrimSpace(questionOpt.StringValue())
+	}
+	answersOpt := getOption(optionMap, "quiz-answers")
+	if question != "" {
+		if answersOpt == nil {
+			respondEphemeral(s, i, "A quiz needs its accepted answers in quiz-answers.")
+			return
+		}
+		answers, err = models.ParseQuizAnswers(answersOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid quiz answers: "+err.Error())
+			return
+		}
+		if drop {
+			respondEphemeral(s, i, "A giveaway can't be both a drop and a quiz.")
+			return
+		}
+	} else if answersOpt != nil {
+		respondEphemeral(s, i, "Quiz answers need a quiz-question to go with them.")
+		return
+	}
+	ignoreCase := false
+	if caseOpt := getOption(optionMap, "quiz-ignore-case"); caseOpt != nil {
+		ignoreCase = caseOpt.BoolValue()
+	}
+	quizAttempts := models.DefaultQuizAttempts
+	if attemptsOpt := getOption(optionMap, "quiz-attempts"); attemptsOpt != nil {
+		quizAttempts = int(attemptsOpt.IntValue())
+	}
+
+	bonusRoles := db.GetBonusRoles(i.GuildID)
+	if bonusOpt := getOption(optionMap, "bonus-entries"); bonusOpt != nil {
+		bonusRoles, err = parseBonusEntries(bonusOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid bonus entries: "+err.Error())
+			return
+		}
+	}
+
+	channelID := i.ChannelID
+	if channelOpt := getOption(optionMap, "channel"); channelOpt != nil {
+		channelID = channelOpt.ChannelValue(nil).ID
+	}
+	var pingRoleID string
+	if pingOpt := getOption(optionMap, "ping-role"); pingOpt != nil {
+		pingRoleID = pingOpt.RoleValue(nil, "").ID
+	}
+
+	ga := &models.Giveaway{
+		GuildID:       i.GuildID,
+		HostID:        i.Member.User.ID,
+		Title:         title,
+		Description:   description,
+		ImageURL:      imageURL,
+		ThumbnailURL:  thumbnailURL,
+		EndTime:       endTime,
+		RequiredRoles: requiredRoles,
+		RoleMode:      roleMode,
+		BlockedRoles:  blockedRoles,
+		MinAccountAge: minAccountAge,
+		MinMemberAge:  minMemberAge,
+		ClaimWindow:   claimWindow,
+		Drop:          drop,
+		Question:      question,
+		Answers:       answers,
+		IgnoreCase:    ignoreCase,
+		QuizAttempts:  quizAttempts,
+		Reminders:     reminders,
+		Participants:  []string{},
+		ChannelID:     channelID,
+		PingRoleID:    pingRoleID,
+		Winners:       winners,
+		Status:        models.StatusActive,
+		Seed:          models.NewSeed(),
+		BonusRoles:    bonusRoles,
+		Entries:       map[string]int{},
+	}
+	if ga.Drop {
+		// Drops have no draw, so there is nothing to commit to.
+		ga.Seed = ""
+	}
+	if err := checkPostPermissions(s, ga); err != nil {
+		respondEphemeral(s, i, err.Error())
+		return
+	}
+	if imageUpload != nil && !startTime.IsZero() {
+		respondEphemeral(s, i, "Uploaded images can't be kept until a scheduled start. Use the image option with a link instead.")
+		return
+	}
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -357,63 +649,172 @@ func createGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		},
 	})
 
-	embed := models.CreateGiveawayEmbed(title, endTime, roleID, 0, winners)
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Emoji:    &discordgo.ComponentEmoji{Name: "🎉"},
-					Style:    discordgo.PrimaryButton,
-					CustomID: "enter_giveaway",
-				},
-				discordgo.Button{
-					Label:    "Participants",
-					Style:    discordgo.SecondaryButton,
-					CustomID: "list_participants_0",
-				},
-			},
-		},
+	if !startTime.IsZero() {
+		ga.ID = newScheduledID()
+		ga.StartTime = startTime
+		ga.Status = models.StatusScheduled
+		db.SaveGiveaway(ga)
+		ScheduleStart(ga)
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: ptr(fmt.Sprintf("Giveaway scheduled to start <t:%d:F> with ID `%s`. Use /scheduled-giveaways to list or cancel it.",
+				startTime.Unix(), ga.ID)),
+		})
+		return
 	}
 
-	msg, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
-		Embed:      embed,
-		Components: components,
-	})
-	if err != nil {
+	if imageUpload != nil {
+		// The upload's link expires, so the file is sent with the message.
+		file, err := models.DownloadImage(imageUpload.URL, models.UploadName(imageUpload.Filename))
+		if err != nil {
+			log.Println("Error downloading image file:", err)
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: ptr("Couldn't read the image file. Please try again or use the image option with a link."),
+			})
+			return
+		}
+		ga.SetImageUpload(file)
+	}
+	if err := postGiveaway(s, ga); err != nil {
 		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: ptr("Error sending message: " + err.Error()),
+			Content: ptr(err.Error()),
 		})
 		return
 	}
+	db.SaveGiveaway(ga)
 
-	ga := &models.Giveaway{
-		ID:           msg.ID,
-		GuildID:      i.GuildID,
-		Title:        title,
-		EndTime:      endTime,
-		RoleID:       roleID,
-		Participants: []string{},
-		ChannelID:    i.ChannelID,
-		MessageID:    msg.ID,
-		Winners:      winners,
-	}
-
-	duration := time.Until(endTime)
-	ga.Timer = time.AfterFunc(duration, func() {
-		models.EndGiveaway(GetSession(), ga)
+	content := "Giveaway created!"
+	if ga.ChannelID != i.ChannelID {
+		content = fmt.Sprintf("Giveaway created in <#%s>!", ga.ChannelID)
+	}
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: ptr(content),
 	})
+}
+
+// postGiveaway sends the giveaway message to the giveaway's channel and starts
+// the giveaway under the message ID. Its errors are meant for users.
+func postGiveaway(s *discordgo.Session, ga *models.Giveaway) error {
+	if err := checkPostPermissions(s, ga); err != nil {
+		return err
+	}
+	embed := models.CreateGiveawayEmbed(ga)
+	components := entryComponents(ga)
+
+	send := &discordgo.MessageSend{
+		Embed:      embed,
+		Components: components,
+	}
+	if ga.PingRoleID != "" {
+		send.Content = fmt.Sprintf("<@&%s>", ga.PingRoleID)
+		send.AllowedMentions = &discordgo.MessageAllowedMentions{Roles: []string{ga.PingRoleID}}
+	}
+	if ga.ImageUpload != nil {
+		send.Files = []*discordgo.File{ga.ImageUpload}
+	}
+	msg, err := s.ChannelMessageSendComplex(ga.ChannelID, send)
+	ga.ImageUpload = nil
+	if err != nil {
+		log.Printf("Error sending giveaway message to channel %s: %v", ga.ChannelID, err)
+		return fmt.Errorf("Discord did not accept the giveaway message in <#%s>. Check my permissions there and try again.", ga.ChannelID)
+	}
+
+	ga.ID = msg.ID
+	ga.MessageID = msg.ID
 
+	ScheduleEnd(ga)
+
+	models.GiveawaysMutex.Lock()
 	models.Giveaways[msg.ID] = ga
-	db.SaveGiveaway(ga)
+	models.GiveawaysMutex.Unlock()
+	return nil
+}
 
-	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Content: ptr("Giveaway created!"),
-	})
+// entryComponents are the buttons of a running giveaway. Remind me only shows
+// when the giveaway has reminders.
+func entryComponents(ga *models.Giveaway) []discordgo.MessageComponent {
+	buttons := []discordgo.MessageComponent{
+		discordgo.Button{
+			Emoji:    &discordgo.ComponentEmoji{Name: "🎉"},
+			Style:    discordgo.PrimaryButton,
+			CustomID: "enter_giveaway",
+		},
+		discordgo.Button{
+			Label:    "Participants",
+			Style:    discordgo.SecondaryButton,
+			CustomID: "list_participants_0",
+		},
+	}
+	if len(ga.Reminders) > 0 {
+		buttons = append(buttons, discordgo.Button{
+			Label:    "Remind me",
+			Emoji:    &discordgo.ComponentEmoji{Name: "🔔"},
+			Style:    discordgo.SecondaryButton,
+			CustomID: "remind_me",
+		})
+	}
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// checkPostPermissions makes sure the bot can post the giveaway message in its
+// channel and ping its role, so users get told what is missing instead of a
+// Discord error.
+func checkPostPermissions(s *discordgo.Session, ga *models.Giveaway) error {
+	perms, err := s.State.UserChannelPermissions(s.State.User.ID, ga.ChannelID)
+	if err != nil {
+		perms, err = s.UserChannelPermissions(s.State.User.ID, ga.ChannelID)
+	}
+	if err != nil {
+		log.Printf("Error checking permissions in channel %s: %v", ga.ChannelID, err)
+		return fmt.Errorf("I can't access <#%s>. Make sure I can see that channel.", ga.ChannelID)
+	}
+
+	var missing []string
+	for _, p := range []struct {
+		bit  int64
+		name string
+	}{
+		{discordgo.PermissionViewChannel, "View Channel"},
+		{discordgo.PermissionSendMessages, "Send Messages"},
+		{discordgo.PermissionEmbedLinks, "Embed Links"},
+	} {
+		if perms&p.bit == 0 {
+			missing = append(missing, p.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("I need the %s permission in <#%s> to post the giveaway there.", strings.Join(missing, ", "), ga.ChannelID)
+	}
+
+	if ga.PingRoleID != "" && perms&discordgo.PermissionMentionEveryone == 0 {
+		role, err := s.State.Role(ga.GuildID, ga.PingRoleID)
+		if err == nil && !role.Mentionable {
+			return fmt.Errorf("I can't ping <@&%s> in <#%s>. Make the role mentionable or give me the Mention Everyone permission.", ga.PingRoleID, ga.ChannelID)
+		}
+	}
+	return nil
+}
+
+// Embed descriptions hold up to 4096 characters, and the entry info and
+// requirements need room too.
+const maxPrizeDescription = 1000
+
+// parseImageURL checks that an image option is a web link Discord can embed.
+func parseImageURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%q is not an http(s) link", raw)
+	}
+	return u.String(), nil
 }
 
 func handleEnterGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, userID, messageID string) {
+	// The checks and the entry happen under the lock, so nobody gets in while
+	// the giveaway is being drawn.
+	models.GiveawaysMutex.Lock()
 	ga, ok := models.Giveaways[messageID]
-	if !ok || time.Now().After(ga.EndTime) {
+	// A paused giveaway may be past its original end time and still running.
+	if !ok || ga.Status != models.StatusActive || !ga.Paused && time.Now().After(ga.EndTime) {
+		models.GiveawaysMutex.Unlock()
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
@@ -424,24 +825,16 @@ func handleEnterGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, u
 		return
 	}
 
-	if ga.RoleID != "" {
-		hasRole := false
-		for _, role := range i.Member.Roles {
-			if role == ga.RoleID {
-				hasRole = true
-				break
-			}
-		}
-		if !hasRole {
-			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "You don't have the required role to join.",
-					Flags:   discordgo.MessageFlagsEphemeral,
-				},
-			})
-			return
-		}
+	if reason := entryRejection(ga, i.Member, userID); reason != "" {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, reason)
+		return
+	}
+
+	if ga.Drop {
+		models.GiveawaysMutex.Unlock()
+		claimDropSlot(s, i, ga, userID)
+		return
 	}
 
 	isParticipant := false
@@ -453,6 +846,7 @@ func handleEnterGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, u
 	}
 
 	if isParticipant {
+		models.GiveawaysMutex.Unlock()
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseModal,
 			Data: &discordgo.InteractionResponseData{
@@ -473,19 +867,65 @@ func handleEnterGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, u
 				},
 			},
 		})
-	} else {
-		ga.Participants = append(ga.Participants, userID)
-		models.UpdateGiveawayEmbed(s, ga)
-		db.SaveParticipants(ga.ID, ga.GuildID, ga.Participants)
+		return
+	}
 
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "You have entered the giveaway!",
-				Flags:   discordgo.MessageFlagsEphemeral,
-			},
-		})
+	if ga.IsQuiz() {
+		if attempts, correct := db.QuizAttempts(ga.ID, ga.GuildID, userID); !correct {
+			models.GiveawaysMutex.Unlock()
+			openQuizModal(s, i, ga, attempts)
+			return
+		}
+	}
+	entries := addParticipant(s, ga, userID, i.Member.Roles)
+	models.GiveawaysMutex.Unlock()
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: enteredMessage(entries),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// entryRejection explains why a member can't enter a running giveaway, or
+// returns "" if they can.
+func entryRejection(ga *models.Giveaway, member *discordgo.Member, userID string) string {
+	if ga.Paused {
+		return "This giveaway is paused. Entries will reopen once it is resumed."
+	}
+	if entry := db.GetBlacklistEntry(ga.GuildID, userID); entry != nil {
+		return blacklistedMessage(entry)
+	}
+	if db.IsRemovedParticipant(ga.ID, ga.GuildID, userID) {
+		return "You were removed from this giveaway by a moderator and can't enter it again."
 	}
+	if reason := ga.CheckRoles(member.Roles); reason != "" {
+		return reason
+	}
+	return ga.CheckAge(userID, member.JoinedAt)
+}
+
+// addParticipant enters a member who passed every check into the giveaway and
+// returns how many entries they got. The caller holds GiveawaysMutex.
+func addParticipant(s *discordgo.Session, ga *models.Giveaway, userID string, roles []string) int {
+	entries := ga.EntriesForRoles(roles)
+	ga.Participants = append(ga.Participants, userID)
+	if ga.Entries == nil {
+		ga.Entries = map[string]int{}
+	}
+	ga.Entries[userID] = entries
+	models.UpdateGiveawayEmbed(s, ga)
+	db.SaveParticipants(ga)
+	return entries
+}
+
+func enteredMessage(entries int) string {
+	if entries > 1 {
+		return fmt.Sprintf("You have entered the giveaway with **%d** entries!", entries)
+	}
+	return "You have entered the giveaway!"
 }
 
 func handleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -522,12 +962,14 @@ func handleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			return
 		}
 
+		models.GiveawaysMutex.Lock()
 		ga, ok := models.Giveaways[messageID]
-		if !ok {
+		if !ok || ga.Status != models.StatusActive {
+			models.GiveawaysMutex.Unlock()
 			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: "Giveaway not found.",
+					Content: "Giveaway not found or has ended.",
 					Flags:   discordgo.MessageFlagsEphemeral,
 				},
 			})
@@ -537,14 +979,10 @@ func handleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			return
 		}
 
-		for idx, p := range ga.Participants {
-			if p == userID {
-				ga.Participants = append(ga.Participants[:idx], ga.Participants[idx+1:]...)
-				break
-			}
-		}
+		ga.RemoveParticipant(userID)
 		models.UpdateGiveawayEmbed(s, ga)
-		db.SaveParticipants(ga.ID, ga.GuildID, ga.Participants)
+		db.SaveParticipants(ga)
+		models.GiveawaysMutex.Unlock()
 
 		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -556,18 +994,376 @@ func handleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		if err != nil {
 			log.Println("Error responding to modal submission:", err)
 		}
+	} else if strings.HasPrefix(data.CustomID, "quiz_answer_modal_") {
+		handleQuizAnswer(s, i, strings.TrimPrefix(data.CustomID, "quiz_answer_modal_"))
 	}
 }
 
-func handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
-	models.GiveawaysMutex.Lock()
-	ga, ok := models.Giveaways[giveawayID]
-	var participants []string
-	if ok {
-		participants = ga.Participants
+func endGiveawayCommand(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		if !hasPermission(s, i) {
+			respondEphemeral(s, i, "You do not have permission to use this command.")
+			return
+		}
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+	if !canManage(s, i, ga) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+	if ga.Status != models.StatusActive {
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+
+	// Drawing talks to Discord several times, so answer before the 3 second
+	// interaction deadline and fill in the result afterwards.
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	content := fmt.Sprintf("Giveaway **%s** has been ended.", escapeMarkdown(ga.Title))
+	if !EndGiveaway(ga) {
+		content = notRunningMessage(ga)
 	}
-	if !ok || ga.GuildID != i.GuildID {
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: ptr(content),
+	})
+}
+
+func cancelGiveawayCommand(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string, notify bool) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+
+	models.GiveawaysMutex.Lock()
+	if ga.Status != models.StatusActive {
 		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+	ga.Status = models.StatusCancelled
+	sched.CancelGiveaway(ga.ID)
+	participants := slices.Clone(ga.Participants)
+	models.GiveawaysMutex.Unlock()
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+
+	db.SetGiveawayStatus(ga.ID, ga.GuildID, models.StatusCancelled)
+	models.CancelGiveaway(s, ga, "No winners will be drawn.")
+
+	content := fmt.Sprintf("Giveaway **%s** has been cancelled.", escapeMarkdown(ga.Title))
+	if notify && len(participants) > 0 {
+		link := fmt.Sprintf("https://discord.com/channels/%s/%s/%s", ga.GuildID, ga.ChannelID, ga.MessageID)
+		dm := fmt.Sprintf("The giveaway **%s** you entered has been cancelled. No winners will be drawn.\n%s", escapeMarkdown(ga.Title), link)
+		failed := 0
+		for _, uid := range participants {
+			if err := sendDM(s, uid, dm); err != nil {
+				log.Printf("Error sending cancellation DM to %s: %v", uid, err)
+				failed++
+			}
+		}
+		content += fmt.Sprintf("\nNotified %d of %d participants.", len(participants)-failed, len(participants))
+	}
+	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: ptr(content),
+	})
+}
+
+func editGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	giveawayID := getOption(optionMap, "id").StringValue()
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+
+	var endTime time.Time
+	if endOpt := getOption(optionMap, "end"); endOpt != nil {
+		t, err := models.ParseEndTime(endOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid end time format: "+err.Error())
+			return
+		}
+		if !t.After(time.Now()) {
+			respondEphemeral(s, i, "The new end time must be in the future.")
+			return
+		}
+		endTime = t
+	}
+	var addedRoles, blockedRoles []string
+	if rolesOpt := getOption(optionMap, "roles"); rolesOpt != nil {
+		var err error
+		addedRoles, err = parseRoleMentions(rolesOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid roles: "+err.Error())
+			return
+		}
+	}
+	if blockedOpt := getOption(optionMap, "blocked-roles"); blockedOpt != nil {
+		var err error
+		blockedRoles, err = parseRoleMentions(blockedOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid blocked roles: "+err.Error())
+			return
+		}
+	}
+	var reminders []time.Duration
+	if reminderOpt := getOption(optionMap, "reminders"); reminderOpt != nil {
+		var err error
+		reminders, err = models.ParseReminders(reminderOpt.StringValue())
+		if err != nil {
+			respondEphemeral(s, i, "Invalid reminders: "+err.Error())
+			return
+		}
+	}
+
+	models.GiveawaysMutex.Lock()
+	if ga.Status != models.StatusActive {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+	if winnerOpt := getOption(optionMap, "winners"); winnerOpt != nil && ga.Drop && int(winnerOpt.IntValue()) <= len(ga.Excluded) {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, fmt.Sprintf("%d slots of this drop are already claimed. Use /end-giveaway to close it.", len(ga.Excluded)))
+		return
+	}
+
+	// Move the end first: if the draw already started, nothing else may
+	// change either.
+	var changes []string
+	if !endTime.IsZero() {
+		if ga.Paused {
+			// UpdateGiveaway doesn't store the time left while paused.
+			ga.Remaining = time.Until(endTime)
+			db.SetGiveawayPaused(ga.ID, ga.GuildID, true, ga.Remaining)
+		} else if !sched.Reschedule(endKey(ga.ID), endTime) {
+			// The end event already fired and the draw is running.
+			models.GiveawaysMutex.Unlock()
+			respondEphemeral(s, i, notRunningMessage(ga))
+			return
+		}
+		ga.EndTime = endTime
+		changes = append(changes, "end time")
+	}
+	if titleOpt := getOption(optionMap, "title"); titleOpt != nil {
+		ga.Title = titleOpt.StringValue()
+		changes = append(changes, "title")
+	}
+	if winnerOpt := getOption(optionMap, "winners"); winnerOpt != nil {
+		if w := int(winnerOpt.IntValue()); w > 0 {
+			ga.Winners = w
+			changes = append(changes, "winners")
+		}
+	}
+	if roleOpt := getOption(optionMap, "role"); roleOpt != nil {
+		ga.RequiredRoles = []string{roleOpt.RoleValue(nil, "").ID}
+		changes = append(changes, "required role")
+	} else if removeOpt := getOption(optionMap, "remove-role"); removeOpt != nil && removeOpt.BoolValue() {
+		ga.RequiredRoles = nil
+		changes = append(changes, "required role")
+	}
+	if getOption(optionMap, "roles") != nil {
+		ga.RequiredRoles = appendUnique(ga.RequiredRoles, addedRoles...)
+		changes = append(changes, "required roles")
+	}
+	if modeOpt := getOption(optionMap, "role-mode"); modeOpt != nil {
+		ga.RoleMode = modeOpt.StringValue()
+		changes = append(changes, "role mode")
+	}
+	if getOption(optionMap, "blocked-roles") != nil {
+		ga.BlockedRoles = blockedRoles
+		changes = append(changes, "blocked roles")
+	}
+	if reminders != nil {
+		cancelReminders(ga)
+		ga.Reminders = reminders
+		changes = append(changes, "reminders")
+	}
+	if (reminders != nil || !endTime.IsZero()) && !ga.Paused {
+		ScheduleReminders(ga)
+	}
+
+	if len(changes) == 0 {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, "Nothing to change. Pass at least one option to edit.")
+		return
+	}
+
+	db.UpdateGiveaway(ga)
+	if reminders != nil {
+		// Remind me comes and goes with the reminders.
+		components := entryComponents(ga)
+		_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         ga.MessageID,
+			Channel:    ga.ChannelID,
+			Embed:      models.CreateGiveawayEmbed(ga),
+			Components: &components,
+		})
+		if err != nil {
+			log.Println("Error updating giveaway buttons:", err)
+		}
+	} else {
+		models.UpdateGiveawayEmbed(s, ga)
+	}
+	models.GiveawaysMutex.Unlock()
+
+	respondEphemeral(s, i, fmt.Sprintf("Updated %s of giveaway **%s**.", strings.Join(changes, ", "), escapeMarkdown(ga.Title)))
+}
+
+func pauseGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+
+	models.GiveawaysMutex.Lock()
+	if ga.Status != models.StatusActive {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+	if ga.Paused {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, fmt.Sprintf("Giveaway **%s** is already paused.", escapeMarkdown(ga.Title)))
+		return
+	}
+	if !sched.Cancel(endKey(ga.ID)) {
+		// The end event already fired and the draw is running.
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+	cancelReminders(ga)
+	ga.Paused = true
+	ga.Remaining = time.Until(ga.EndTime)
+	db.SetGiveawayPaused(ga.ID, ga.GuildID, true, ga.Remaining)
+	models.UpdateGiveawayEmbed(s, ga)
+	models.GiveawaysMutex.Unlock()
+
+	respondEphemeral(s, i, fmt.Sprintf("Giveaway **%s** is paused with %s left.", escapeMarkdown(ga.Title), ga.Remaining.Round(time.Second)))
+}
+
+func resumeGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+
+	models.GiveawaysMutex.Lock()
+	if ga.Status != models.StatusActive {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+	if !ga.Paused {
+		models.GiveawaysMutex.Unlock()
+		respondEphemeral(s, i, fmt.Sprintf("Giveaway **%s** is not paused.", escapeMarkdown(ga.Title)))
+		return
+	}
+	ga.Paused = false
+	ga.EndTime = time.Now().Add(ga.Remaining)
+	ga.Remaining = 0
+	ScheduleEnd(ga)
+	db.SetGiveawayPaused(ga.ID, ga.GuildID, false, 0)
+	db.UpdateGiveaway(ga)
+	models.UpdateGiveawayEmbed(s, ga)
+	models.GiveawaysMutex.Unlock()
+
+	respondEphemeral(s, i, fmt.Sprintf("Giveaway **%s** has been resumed and ends <t:%d:R>.", escapeMarkdown(ga.Title), ga.EndTime.Unix()))
+}
+
+// notRunningMessage explains why a giveaway that is no longer active can't be
+// changed.
+func notRunningMessage(ga *models.Giveaway) string {
+	if ga.Status == models.StatusScheduled {
+		return fmt.Sprintf("Giveaway **%s** has not started yet.", escapeMarkdown(ga.Title))
+	}
+	if ga.Status == models.StatusCancelled {
+		return fmt.Sprintf("Giveaway **%s** was cancelled.", escapeMarkdown(ga.Title))
+	}
+	return fmt.Sprintf("Giveaway **%s** has already ended.", escapeMarkdown(ga.Title))
+}
+
+func sendDM(s *discordgo.Session, userID string, content string) error {
+	ch, err := s.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.ChannelMessageSend(ch.ID, content)
+	return err
+}
+
+// findGiveaway looks up a giveaway among the running ones first and falls back
+// to the database, where ended giveaways are kept.
+func findGiveaway(giveawayID string, guildID string) (*models.Giveaway, bool) {
+	models.GiveawaysMutex.RLock()
+	ga, ok := models.Giveaways[giveawayID]
+	models.GiveawaysMutex.RUnlock()
+	if ok {
+		return ga, ga.GuildID == guildID
+	}
+	ga, err := db.LoadGiveaway(giveawayID, guildID)
+	if err != nil {
+		return nil, false
+	}
+	return ga, true
+}
+
+func handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	allowed := ok && canManage(s, i, ga) || hasPermission(s, i)
+	if !allowed {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "You do not have permissions to reroll.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+	if !ok {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
@@ -577,20 +1373,27 @@ func handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, giveaway
 		})
 		return
 	}
-	models.GiveawaysMutex.Unlock()
-
-	eligible := make([]string, 0, len(participants))
-	winnerSet := make(map[string]bool)
-	for _, w := range ga.Excluded {
-		winnerSet[w] = true
+	if ga.Drop {
+		respondEphemeral(s, i, "Drops have no draw, so there is nothing to reroll.")
+		return
 	}
-
-	for _, p := range ga.Participants {
-		if !winnerSet[p] {
-			eligible = append(eligible, p)
-		}
+	if ga.Status == models.StatusActive {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "This giveaway has not ended yet.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
 	}
-	if len(eligible) == 0 {
+	if ga.Status == models.StatusCancelled {
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+
+	win, round, ok := rerollWinner(ga)
+	if !ok {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
@@ -600,30 +1403,63 @@ func handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, giveaway
 		})
 		return
 	}
-	winnerIdx := rand.Intn(len(eligible))
-	winnerID := eligible[winnerIdx]
-	ga.Excluded = append(ga.Excluded, winnerID)
-	rerollComponents := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "Reroll",
-					Style:    discordgo.PrimaryButton,
-					CustomID: "reroll_" + ga.ID,
-				},
-			},
+	announceReroll(s, ga, win, round, "")
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: "Reroll complete!",
+			Flags:   discordgo.MessageFlagsEphemeral,
 		},
+	})
+}
+
+// rerollWinner draws one more winner from everyone who has not won yet and
+// records the win. It returns false if there is no one left to draw.
+func rerollWinner(ga *models.Giveaway) (models.Win, string, bool) {
+	models.GiveawaysMutex.Lock()
+	defer models.GiveawaysMutex.Unlock()
+	ensureSeed(ga)
+	round := models.RerollRound(len(ga.Excluded))
+	drawn := models.Draw(ga.Seed, round, ga.Participants, ga.Entries, ga.Excluded, 1)
+	if len(drawn) == 0 {
+		return models.Win{}, "", false
+	}
+	win := models.Win{
+		GiveawayID:    ga.ID,
+		GuildID:       ga.GuildID,
+		UserID:        drawn[0],
+		WonAt:         time.Now(),
+		Source:        models.WinSourceReroll,
+		ClaimDeadline: ga.ClaimDeadline(time.Now()),
 	}
+	ga.Excluded = append(ga.Excluded, win.UserID)
+	db.SaveWinners(ga.ID, ga.GuildID, []string{win.UserID}, win.Source, win.ClaimDeadline)
+	return win, round, true
+}
 
+// announceReroll posts a rerolled winner under the giveaway and starts their
+// claim window. note is added to the description when set.
+func announceReroll(s *discordgo.Session, ga *models.Giveaway, win models.Win, round string, note string) {
 	embed := &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("New Winner of Giveaway: %s", ga.Title),
-		Description: fmt.Sprintf("<@%s>", winnerID),
+		Description: fmt.Sprintf("<@%s>", win.UserID),
 		Color:       0x00ff00,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Round %s • verify with /verify-giveaway id:%s", round, ga.ID),
+		},
+	}
+	if note != "" {
+		embed.Description += "\n\n" + note
 	}
-	_, err := s.ChannelMessageSendComplex(ga.ChannelID, &discordgo.MessageSend{
-		Content:    fmt.Sprintf("<@%s>", winnerID),
+	claimOpen := !win.ClaimDeadline.IsZero()
+	if claimOpen {
+		embed.Fields = []*discordgo.MessageEmbedField{models.ClaimsField([]models.Win{win})}
+	}
+	msg, err := s.ChannelMessageSendComplex(ga.ChannelID, &discordgo.MessageSend{
+		Content:    fmt.Sprintf("<@%s>", win.UserID),
 		Embed:      embed,
-		Components: rerollComponents,
+		Components: models.WinnerComponents(ga, claimOpen),
 		Reference: &discordgo.MessageReference{
 			MessageID: ga.MessageID,
 			ChannelID: ga.ChannelID,
@@ -631,19 +1467,18 @@ func handleReroll(s *discordgo.Session, i *discordgo.InteractionCreate, giveaway
 	})
 	if err != nil {
 		log.Println("Error sending reroll message:", err)
+		return
 	}
-
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: "Reroll complete!",
-			Flags:   discordgo.MessageFlagsEphemeral,
-		},
-	})
+	win.MessageID = msg.ID
+	db.SetWinnerMessage(ga.ID, ga.GuildID, []string{win.UserID}, msg.ID)
+	if claimOpen {
+		ScheduleClaim(win)
+	}
+	notifyRerolled(s, ga, win.UserID, msg.ID)
 }
 
 func showParticipants(s *discordgo.Session, i *discordgo.InteractionCreate, page int, messageID string) {
-	ga, ok := models.Giveaways[messageID]
+	ga, ok := findGiveaway(messageID, i.GuildID)
 	if !ok {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -678,7 +1513,11 @@ func showParticipants(s *discordgo.Session, i *discordgo.InteractionCreate, page
 		if err == nil {
 			name = user.Username
 		}
-		entries = append(entries, fmt.Sprintf("<@%s> (%s)", uid, name))
+		if n := ga.EntriesOf(uid); n > 1 {
+			entries = append(entries, fmt.Sprintf("<@%s> (%s) ×%d", uid, name, n))
+		} else {
+			entries = append(entries, fmt.Sprintf("<@%s> (%s)", uid, name))
+		}
 	}
 
 	description := strings.Join(entries, "\n")
@@ -687,7 +1526,7 @@ func showParticipants(s *discordgo.Session, i *discordgo.InteractionCreate, page
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title:       fmt.Sprintf("Participants (%d total)", total),
+		Title:       fmt.Sprintf("Participants (%d total, %d entries)", total, ga.TotalEntries()),
 		Description: description,
 		Color:       0x00ff00,
 		Footer: &discordgo.MessageEmbedFooter{
@@ -747,7 +1586,7 @@ func listGiveaways(s *discordgo.Session, i *discordgo.InteractionCreate, userID
 		if ga.GuildID != i.GuildID {
 			continue
 		}
-		if now.After(ga.EndTime) {
+		if ga.Status != models.StatusActive || !ga.Paused && now.After(ga.EndTime) {
 			continue
 		}
 
@@ -765,6 +1604,9 @@ func listGiveaways(s *discordgo.Session, i *discordgo.InteractionCreate, userID
 
 		loc, _ := time.LoadLocation("Etc/UTC")
 		timeLeft := fmt.Sprintf("<t:%d:R>", ga.EndTime.In(loc).Unix())
+		if ga.Paused {
+			timeLeft = fmt.Sprintf("paused, %s left", ga.Remaining.Round(time.Second))
+		}
 
 		field := &discordgo.MessageEmbedField{
 			Name:   fmt.Sprintf("%s (ID: `%s`)", titleLink, ga.MessageID),
@@ -814,3 +1656,57 @@ func listGiveaways(s *discordgo.Session, i *discordgo.InteractionCreate, userID
 func ptr(s string) *string {
 	return &s
 }
+
+func ptrFloat(f float64) *float64 {
+	return &f
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println("Error responding to interaction:", err)
+	}
+}
+
+func mentions(userIDs []string) string {
+	if len(userIDs) == 0 {
+		return "*nobody*"
+	}
+	var out []string
+	for _, uid := range userIDs {
+		out = append(out, "<@"+uid+">")
+	}
+	return strings.Join(out, ", ")
+}
+
+// truncate shortens s to at most n bytes, cutting on a rune boundary so the
+// result stays valid UTF-8.
+func truncate(s string, n int) string {
+	if s == "" {
+		return "*none*"
+	}
+	if len(s) <= n {
+		return s
+	}
+	cut := n - len("…")
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
+
+func respondEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
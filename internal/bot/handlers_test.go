@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"slices"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"", 10, "*none*"},
+		{"short", 10, "short"},
+		{"exactly 10", 10, "exactly 10"},
+		{"abcdefghijk", 10, "abcdefg…"},
+		{"ééééé", 9, "ééé…"},
+		{"ééééé", 8, "éé…"},
+		{"ab🎉🎉", 9, "ab🎉…"},
+		{"ab🎉🎉", 8, "ab…"},
+		{"🎉🎉🎉", 10, "🎉…"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if (tt.s != "" && len(got) > tt.n) || !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) = %q is too long or not valid UTF-8", tt.s, tt.n, got)
+		}
+	}
+}
+
+func TestParseRoleMentions(t *testing.T) {
+	const booster, patron = "123456789012345678", "876543210987654321"
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{input: ""},
+		{input: "none"},
+		{input: "<@&123456789012345678>", want: []string{booster}},
+		{input: "<@&123456789012345678> <@&876543210987654321>", want: []string{booster, patron}},
+		{input: "123456789012345678, 876543210987654321", want: []string{booster, patron}},
+		{input: "<@&123456789012345678> 123456789012345678", want: []string{booster}},
+		{input: "<@&42>", want: []string{"42"}},
+		{input: "12345", want: nil},
+		{input: "<@123456789012345678>", wantErr: true},
+		{input: "<@!123456789012345678>", wantErr: true},
+		{input: "<@&876543210987654321> <@123456789012345678>", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseRoleMentions(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseRoleMentions(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRoleMentions(%q): %v", tt.input, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseRoleMentions(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// internal/bot/notify.go
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+func messageLink(ga *models.Giveaway, messageID string) string {
+	if messageID == "" {
+		messageID = ga.MessageID
+	}
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", ga.GuildID, ga.ChannelID, messageID)
+}
+
+// notifyWinners DMs each winner a link to the announcement that names them
+// and returns who could not be reached.
+func notifyWinners(s *discordgo.Session, ga *models.Giveaway, winners []string, announcementID string) []string {
+	dm := fmt.Sprintf("🎉 You won the giveaway **%s**!\n%s", escapeMarkdown(ga.Title), messageLink(ga, announcementID))
+	if ga.ClaimWindow > 0 {
+		dm += fmt.Sprintf("\nPress **Claim** on the announcement within %s or the prize goes to someone else.", models.FormatAge(ga.ClaimWindow))
+	}
+	var failed []string
+	for _, uid := range winners {
+		if err := sendDM(s, uid, dm); err != nil {
+			log.Printf("Error sending winner DM to %s: %v", uid, err)
+			failed = append(failed, uid)
+		}
+	}
+	return failed
+}
+
+// notifyHost DMs the host what happened to their giveaway. It returns false if
+// the DM could not be delivered.
+func notifyHost(s *discordgo.Session, ga *models.Giveaway, content string) bool {
+	if ga.HostID == "" {
+		return true
+	}
+	if err := sendDM(s, ga.HostID, content); err != nil {
+		log.Printf("Error sending host DM to %s: %v", ga.HostID, err)
+		return false
+	}
+	return true
+}
+
+// hostSummary describes the result of a draw for the host.
+func hostSummary(ga *models.Giveaway, winners []string, announcementID string) string {
+	if len(winners) == 0 {
+		return fmt.Sprintf("Your giveaway **%s** has ended with no participants.\n%s", escapeMarkdown(ga.Title), messageLink(ga, ""))
+	}
+	return fmt.Sprintf("Your giveaway **%s** has ended.\nParticipants: **%d** (%d entries)\nWinners: %s\n%s",
+		escapeMarkdown(ga.Title), len(ga.Participants), ga.TotalEntries(), mentions(winners), messageLink(ga, announcementID))
+}
+
+// notifyEnded sends the DMs for a finished draw and reports the ones that
+// could not be delivered in the giveaway channel.
+func notifyEnded(s *discordgo.Session, ga *models.Giveaway, winners []string, announcementID string) {
+	failed := notifyWinners(s, ga, winners, announcementID)
+	hostReached := notifyHost(s, ga, hostSummary(ga, winners, announcementID))
+	reportDMFailures(s, ga, failed, hostReached)
+}
+
+// notifyRerolled sends the DMs for a rerolled winner.
+func notifyRerolled(s *discordgo.Session, ga *models.Giveaway, winnerID string, announcementID string) {
+	failed := notifyWinners(s, ga, []string{winnerID}, announcementID)
+	hostReached := notifyHost(s, ga, fmt.Sprintf("A new winner was drawn for your giveaway **%s**: <@%s>\n%s",
+		escapeMarkdown(ga.Title), winnerID, messageLink(ga, announcementID)))
+	reportDMFailures(s, ga, failed, hostReached)
+}
+
+// reportDMFailures tells the channel who missed their DM, e.g. because they
+// have DMs from server members turned off, without pinging anyone again.
+func reportDMFailures(s *discordgo.Session, ga *models.Giveaway, winners []string, hostReached bool) {
+	var lines []string
+	if len(winners) > 0 {
+		lines = append(lines, fmt.Sprintf("Couldn't DM %s about their win. Please check the announcement above.", mentions(winners)))
+	}
+	if !hostReached {
+		lines = append(lines, fmt.Sprintf("Couldn't DM the host <@%s> the results.", ga.HostID))
+	}
+	if len(lines) == 0 {
+		return
+	}
+	_, err := s.ChannelMessageSendComplex(ga.ChannelID, &discordgo.MessageSend{
+		Content:         "⚠️ " + strings.Join(lines, "\n"),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Println("Error reporting DM failures:", err)
+	}
+}
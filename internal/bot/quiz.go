@@ -0,0 +1,168 @@
+// internal/bot/quiz.go
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	maxQuizQuestion = 300
+	maxQuizAttempts = 10
+	maxQuizAnswer   = 200
+	// Discord cuts modal labels off after 45 characters.
+	maxModalLabel = 45
+)
+
+// openQuizModal asks a member the question of a quiz giveaway, unless they
+// have no attempts left.
+func openQuizModal(s *discordgo.Session, i *discordgo.InteractionCreate, ga *models.Giveaway, attempts int) {
+	left := ga.QuizAttempts - attempts
+	if left <= 0 {
+		respondEphemeral(s, i, fmt.Sprintf("You have used all %d attempts at this giveaway's question.", ga.QuizAttempts))
+		return
+	}
+
+	label := ga.Question
+	if len([]rune(label)) > maxModalLabel {
+		label = "Your answer to the question in the giveaway"
+	}
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "quiz_answer_modal_" + ga.MessageID,
+			Title:    fmt.Sprintf("Quiz (%d of %d attempts left)", left, ga.QuizAttempts),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "quiz_answer",
+							Label:     label,
+							Style:     discordgo.TextInputShort,
+							Required:  true,
+							MaxLength: maxQuizAnswer,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// handleQuizAnswer records an answer from the quiz modal and enters the member
+// if it is correct. Attempts are counted under GiveawaysMutex so submitting
+// several modals at once can't exceed the limit.
+func handleQuizAnswer(s *discordgo.Session, i *discordgo.InteractionCreate, messageID string) {
+	userID := i.Member.User.ID
+	var answer string
+	for _, component := range i.ModalSubmitData().Components {
+		if actionRow, ok := component.(*discordgo.ActionsRow); ok {
+			for _, comp := range actionRow.Components {
+				if textInput, ok := comp.(*discordgo.TextInput); ok && textInput.CustomID == "quiz_answer" {
+					answer = textInput.Value
+				}
+			}
+		}
+	}
+
+	models.GiveawaysMutex.Lock()
+	defer models.GiveawaysMutex.Unlock()
+	ga, ok := models.Giveaways[messageID]
+	if !ok || ga.Status != models.StatusActive || !ga.IsQuiz() {
+		respondEphemeral(s, i, "Giveaway not found or has ended.")
+		return
+	}
+	if ga.Paused {
+		respondEphemeral(s, i, "This giveaway is paused. Entries will reopen once it is resumed.")
+		return
+	}
+	for _, p := range ga.Participants {
+		if p == userID {
+			respondEphemeral(s, i, "You are already in this giveaway.")
+			return
+		}
+	}
+	attempts, _ := db.QuizAttempts(ga.ID, ga.GuildID, userID)
+	if attempts >= ga.QuizAttempts {
+		respondEphemeral(s, i, fmt.Sprintf("You have used all %d attempts at this giveaway's question.", ga.QuizAttempts))
+		return
+	}
+
+	correct := ga.CheckAnswer(answer)
+	db.RecordQuizAnswer(models.QuizAnswer{
+		GiveawayID: ga.ID,
+		GuildID:    ga.GuildID,
+		UserID:     userID,
+		Answer:     answer,
+		Correct:    correct,
+		AnsweredAt: time.Now(),
+	})
+	if !correct {
+		left := ga.QuizAttempts - attempts - 1
+		if left == 0 {
+			respondEphemeral(s, i, "❌ That's not right, and it was your last attempt.")
+		} else {
+			respondEphemeral(s, i, fmt.Sprintf("❌ That's not right. You have %d attempts left.", left))
+		}
+		return
+	}
+
+	entries := addParticipant(s, ga, userID, i.Member.Roles)
+	respondEphemeral(s, i, "✅ Correct! "+enteredMessage(entries))
+}
+
+// quizAnswers lists the answers given to a quiz giveaway for its host and
+// moderators to review.
+func quizAnswers(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string, userID string) {
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		if !hasPermission(s, i) {
+			respondEphemeral(s, i, "You do not have permission to use this command.")
+			return
+		}
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+	if !canManage(s, i, ga) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+	if !ga.IsQuiz() {
+		respondEphemeral(s, i, "This giveaway has no quiz question.")
+		return
+	}
+
+	answers := db.LoadQuizAnswers(ga.ID, ga.GuildID, userID)
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("Quiz answers for %s", ga.Title),
+		Color: 0x00ff00,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Question", Value: ga.Question},
+			{Name: "Accepted answers", Value: truncate(strings.Join(ga.Answers, " | "), 1024)},
+		},
+	}
+	if len(answers) == 0 {
+		embed.Description = "Nobody has answered yet."
+		respondEmbed(s, i, embed)
+		return
+	}
+
+	correct := 0
+	var lines []string
+	for _, a := range answers {
+		mark := "❌"
+		if a.Correct {
+			mark = "✅"
+			correct++
+		}
+		lines = append(lines, fmt.Sprintf("%s <@%s> `%s` <t:%d:R>", mark, a.UserID, strings.ReplaceAll(a.Answer, "`", "'"), a.AnsweredAt.Unix()))
+	}
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%d answers, %d correct", len(answers), correct)}
+	embed.Description = truncate(strings.Join(lines, "\n"), 4096)
+	respondEmbed(s, i, embed)
+}
@@ -0,0 +1,130 @@
+// internal/bot/reminders.go
+package bot
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/scheduler"
+	"github.com/bwmarrin/discordgo"
+)
+
+func reminderKey(giveawayID string, offset time.Duration) scheduler.Key {
+	return scheduler.Key{GiveawayID: giveawayID, Kind: scheduler.KindReminder, Tag: offset.String()}
+}
+
+// ScheduleReminders queues the reminders of a giveaway that are still ahead
+// and drops the ones whose time has passed, e.g. after the end moved closer.
+func ScheduleReminders(ga *models.Giveaway) {
+	now := time.Now()
+	for _, offset := range ga.Reminders {
+		key := reminderKey(ga.ID, offset)
+		at := ga.EndTime.Add(-offset)
+		if !at.After(now) {
+			sched.Cancel(key)
+			continue
+		}
+		sched.Schedule(key, at, func() {
+			sendReminder(ga, offset)
+		})
+	}
+}
+
+func cancelReminders(ga *models.Giveaway) {
+	for _, offset := range ga.Reminders {
+		sched.Cancel(reminderKey(ga.ID, offset))
+	}
+}
+
+// sendReminder posts that a giveaway ends soon, editing the previous reminder
+// if there is one, and DMs the participants who asked for it.
+func sendReminder(ga *models.Giveaway, offset time.Duration) {
+	models.GiveawaysMutex.RLock()
+	running := ga.Status == models.StatusActive && !ga.Paused
+	participants := slices.Clone(ga.Participants)
+	endTime := ga.EndTime
+	models.GiveawaysMutex.RUnlock()
+	if !running {
+		return
+	}
+
+	s := GetSession()
+	content := fmt.Sprintf("⏰ **%s** ends <t:%d:R>! Press 🎉 on the giveaway to enter.", escapeMarkdown(ga.Title), endTime.Unix())
+	postReminder(s, ga, content)
+
+	dm := fmt.Sprintf("⏰ The giveaway **%s** you entered ends <t:%d:R>.\n%s", escapeMarkdown(ga.Title), endTime.Unix(), messageLink(ga, ""))
+	optIns := db.LoadReminderOptIns(ga.ID, ga.GuildID)
+	// DMs go out one by one and would hold up every other scheduled event.
+	go func() {
+		for _, uid := range optIns {
+			if !slices.Contains(participants, uid) {
+				continue
+			}
+			if err := sendDM(s, uid, dm); err != nil {
+				log.Printf("Error sending %s reminder DM to %s: %v", models.FormatAge(offset), uid, err)
+			}
+		}
+	}()
+}
+
+func postReminder(s *discordgo.Session, ga *models.Giveaway, content string) {
+	if ga.ReminderMsgID != "" {
+		_, err := s.ChannelMessageEdit(ga.ChannelID, ga.ReminderMsgID, content)
+		if err == nil {
+			return
+		}
+		// The reminder was probably deleted, post a new one.
+		log.Printf("Error editing reminder message %s in channel %s: %v", ga.ReminderMsgID, ga.ChannelID, err)
+	}
+	msg, err := s.ChannelMessageSendComplex(ga.ChannelID, &discordgo.MessageSend{
+		Content: content,
+		Reference: &discordgo.MessageReference{
+			MessageID: ga.MessageID,
+			ChannelID: ga.ChannelID,
+		},
+	})
+	if err != nil {
+		log.Println("Error sending reminder message:", err)
+		return
+	}
+	ga.ReminderMsgID = msg.ID
+	db.SetReminderMessage(ga.ID, ga.GuildID, msg.ID)
+}
+
+// handleRemindMe turns reminder DMs for a giveaway on or off for the user.
+func handleRemindMe(s *discordgo.Session, i *discordgo.InteractionCreate, userID string, messageID string) {
+	models.GiveawaysMutex.RLock()
+	ga, ok := models.Giveaways[messageID]
+	var entered bool
+	if ok {
+		entered = slices.Contains(ga.Participants, userID)
+	}
+	models.GiveawaysMutex.RUnlock()
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+	if ga.Status != models.StatusActive {
+		respondEphemeral(s, i, notRunningMessage(ga))
+		return
+	}
+
+	on, err := db.ToggleReminderOptIn(ga.ID, ga.GuildID, userID)
+	if err != nil {
+		respondEphemeral(s, i, "Could not save your reminder, please try again.")
+		return
+	}
+	if !on {
+		respondEphemeral(s, i, fmt.Sprintf("🔕 You won't get reminder DMs for **%s** anymore.", escapeMarkdown(ga.Title)))
+		return
+	}
+	content := fmt.Sprintf("🔔 You'll get a DM %s before **%s** ends.", models.FormatReminders(ga.Reminders), escapeMarkdown(ga.Title))
+	if !entered {
+		content += " Press 🎉 to enter, reminders only go to participants."
+	}
+	respondEphemeral(s, i, content)
+}
@@ -0,0 +1,132 @@
+// internal/bot/scheduled.go
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/scheduler"
+	"github.com/bwmarrin/discordgo"
+)
+
+// newScheduledID names a giveaway until it is posted and takes the ID of its
+// message.
+func newScheduledID() string {
+	return "s" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+func startKey(giveawayID string) scheduler.Key {
+	return scheduler.Key{GiveawayID: giveawayID, Kind: scheduler.KindStart}
+}
+
+// ScheduleStart queues the posting of a scheduled giveaway for its start
+// time. Giveaways whose start passed while the bot was offline are posted as
+// soon as the scheduler starts.
+func ScheduleStart(ga *models.Giveaway) {
+	sched.Schedule(startKey(ga.ID), ga.StartTime, func() {
+		startGiveaway(ga)
+	})
+}
+
+// startGiveaway posts a scheduled giveaway. If it can't be posted the
+// giveaway is cancelled and the host is told why. The row is claimed before
+// posting, so a crash halfway through never posts the giveaway twice.
+func startGiveaway(ga *models.Giveaway) {
+	s := GetSession()
+	scheduledID := ga.ID
+	if !time.Now().Before(ga.EndTime) {
+		log.Printf("Scheduled giveaway %s would already have ended; cancelling it", scheduledID)
+		db.SetGiveawayStatus(scheduledID, ga.GuildID, models.StatusCancelled)
+		notifyHost(s, ga, fmt.Sprintf("Your scheduled giveaway **%s** was cancelled because its end time passed while the bot was offline.",
+			escapeMarkdown(ga.Title)))
+		return
+	}
+
+	if !db.ClaimScheduledGiveaway(scheduledID, ga.GuildID) {
+		log.Printf("Scheduled giveaway %s is not scheduled anymore; not posting it", scheduledID)
+		return
+	}
+	ga.Status = models.StatusActive
+	ga.Participants = []string{}
+	if err := postGiveaway(s, ga); err != nil {
+		log.Printf("Error posting scheduled giveaway %s: %v", scheduledID, err)
+		ga.Status = models.StatusCancelled
+		db.SetGiveawayStatus(scheduledID, ga.GuildID, models.StatusCancelled)
+		notifyHost(s, ga, fmt.Sprintf("Your scheduled giveaway **%s** could not be posted in <#%s> and was cancelled: %v",
+			escapeMarkdown(ga.Title), ga.ChannelID, err))
+		return
+	}
+	db.StartScheduledGiveaway(scheduledID, ga.GuildID, ga.ID)
+}
+
+// CancelInterruptedStart cancels a scheduled giveaway that was being posted
+// when the bot stopped. Posting it again could duplicate it, so the host is
+// asked to check the channel instead.
+func CancelInterruptedStart(ga *models.Giveaway) {
+	log.Printf("Scheduled giveaway %s was interrupted while being posted; cancelling it", ga.ID)
+	ga.Status = models.StatusCancelled
+	db.SetGiveawayStatus(ga.ID, ga.GuildID, models.StatusCancelled)
+	notifyHost(GetSession(), ga, fmt.Sprintf("The bot stopped while posting your scheduled giveaway **%s** in <#%s>, so it was cancelled. "+
+		"If its message was posted, it won't accept entries; please create the giveaway again.",
+		escapeMarkdown(ga.Title), ga.ChannelID))
+}
+
+func scheduledGiveaways(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0]
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(sub.Options))
+	for _, opt := range sub.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	switch sub.Name {
+	case "list":
+		giveaways, err := db.LoadGuildScheduledGiveaways(i.GuildID)
+		if err != nil {
+			respondEphemeral(s, i, "Could not load scheduled giveaways, please try again.")
+			return
+		}
+		if len(giveaways) == 0 {
+			respondEphemeral(s, i, "No giveaways are scheduled.")
+			return
+		}
+		var lines []string
+		for _, ga := range giveaways {
+			lines = append(lines, fmt.Sprintf("`%s` **%s** in <#%s>, starts <t:%d:R>, ends <t:%d:F>",
+				ga.ID, escapeMarkdown(ga.Title), ga.ChannelID, ga.StartTime.Unix(), ga.EndTime.Unix()))
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("Scheduled Giveaways (%d)", len(giveaways)),
+			Description: truncate(strings.Join(lines, "\n"), 4096),
+			Color:       0x00ff00,
+		})
+	case "cancel":
+		id := getOption(optionMap, "id").StringValue()
+		ga, err := db.LoadGiveaway(id, i.GuildID)
+		if err != nil {
+			respondEphemeral(s, i, "Scheduled giveaway not found.")
+			return
+		}
+		if ga.Status != models.StatusScheduled {
+			respondEphemeral(s, i, fmt.Sprintf("Giveaway **%s** is not scheduled anymore.", escapeMarkdown(ga.Title)))
+			return
+		}
+		// The start event runs on the scheduler goroutine, so once it is
+		// removed from the queue the giveaway can't be posted anymore.
+		if !sched.Cancel(startKey(ga.ID)) {
+			respondEphemeral(s, i, fmt.Sprintf("Giveaway **%s** is starting right now. Use /cancel-giveaway once it is posted.", escapeMarkdown(ga.Title)))
+			return
+		}
+		db.SetGiveawayStatus(ga.ID, ga.GuildID, models.StatusCancelled)
+		respondEphemeral(s, i, fmt.Sprintf("Scheduled giveaway **%s** has been cancelled.", escapeMarkdown(ga.Title)))
+	}
+}
@@ -0,0 +1,229 @@
+// internal/bot/series.go
+package bot
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/scheduler"
+	"github.com/bwmarrin/discordgo"
+)
+
+// seriesKey identifies the next occurrence of a series. Series have no
+// giveaway of their own, so the key carries the series ID.
+func seriesKey(seriesID string) scheduler.Key {
+	return scheduler.Key{GiveawayID: seriesID, Kind: scheduler.KindSeries}
+}
+
+// ScheduleSeries queues the next occurrence of a series. Occurrences missed
+// while the bot was offline are posted once as soon as the scheduler starts.
+func ScheduleSeries(sr *models.Series) {
+	sched.Schedule(seriesKey(sr.ID), sr.NextRun, func() {
+		runSeries(sr)
+	})
+}
+
+// runSeries posts the next occurrence of a series and queues the one after.
+func runSeries(sr *models.Series) {
+	if current := db.GetSeries(sr.ID, sr.GuildID); current == nil || !current.Active {
+		return
+	}
+	rec, err := models.ParseRecurrence(sr.Schedule)
+	if err != nil {
+		log.Printf("Series %s has an invalid schedule %q: %v", sr.ID, sr.Schedule, err)
+		return
+	}
+
+	s := GetSession()
+	now := time.Now()
+	n := sr.Occurrences + 1
+	ga := &models.Giveaway{
+		GuildID:       sr.GuildID,
+		HostID:        sr.HostID,
+		Title:         sr.OccurrenceTitle(n, now),
+		EndTime:       now.Add(sr.Duration),
+		RequiredRoles: sr.RequiredRoles,
+		RoleMode:      models.RoleModeAny,
+		Participants:  []string{},
+		ChannelID:     sr.ChannelID,
+		Winners:       sr.Winners,
+		Status:        models.StatusActive,
+		Seed:          models.NewSeed(),
+		BonusRoles:    db.GetBonusRoles(sr.GuildID),
+		Reminders:     db.GetReminders(sr.GuildID),
+		Entries:       map[string]int{},
+		SeriesID:      sr.ID,
+	}
+	if err := postGiveaway(s, ga); err != nil {
+		log.Printf("Error posting occurrence %d of series %s: %v", n, sr.ID, err)
+		notifyHost(s, ga, fmt.Sprintf("The recurring giveaway **%s** could not be posted in <#%s>: %v",
+			escapeMarkdown(ga.Title), ga.ChannelID, err))
+	} else {
+		db.SaveGiveaway(ga)
+		sr.Occurrences = n
+	}
+
+	next := rec.Next(sr.NextRun)
+	for !next.IsZero() && !next.After(now) {
+		next = rec.Next(next)
+	}
+	if next.IsZero() {
+		log.Printf("Series %s has no further occurrences; stopping it", sr.ID)
+		db.StopSeries(sr.ID, sr.GuildID)
+		return
+	}
+	sr.NextRun = next
+	db.SetSeriesRun(sr.ID, sr.Occurrences, next)
+	ScheduleSeries(sr)
+}
+
+// ScheduleAllSeries queues the next occurrence of every running series.
+func ScheduleAllSeries() {
+	for _, sr := range db.LoadActiveSeries() {
+		ScheduleSeries(sr)
+	}
+}
+
+func giveawaySeries(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0]
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(sub.Options))
+	for _, opt := range sub.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	switch sub.Name {
+	case "create":
+		createSeries(s, i, optionMap)
+	case "list":
+		series := db.LoadGuildSeries(i.GuildID)
+		if len(series) == 0 {
+			respondEphemeral(s, i, "No recurring giveaways are set up.")
+			return
+		}
+		var lines []string
+		for _, sr := range series {
+			line := fmt.Sprintf("`%s` **%s** in <#%s>, %s for %s, %d posted",
+				sr.ID, escapeMarkdown(sr.Title), sr.ChannelID, sr.Schedule, models.FormatAge(sr.Duration), sr.Occurrences)
+			if sr.Active {
+				line += fmt.Sprintf(", next <t:%d:R>", sr.NextRun.Unix())
+			} else {
+				line += " (stopped)"
+			}
+			lines = append(lines, line)
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("Recurring Giveaways (%d)", len(series)),
+			Description: truncate(strings.Join(lines, "\n"), 4096),
+			Color:       0x00ff00,
+		})
+	case "history":
+		id := getOption(optionMap, "id").StringValue()
+		sr := db.GetSeries(id, i.GuildID)
+		if sr == nil {
+			respondEphemeral(s, i, "Recurring giveaway not found.")
+			return
+		}
+		giveaways := db.LoadSeriesGiveaways(sr.ID, sr.GuildID)
+		if len(giveaways) == 0 {
+			respondEphemeral(s, i, fmt.Sprintf("**%s** has not posted any giveaways yet.", escapeMarkdown(sr.Title)))
+			return
+		}
+		var lines []string
+		for _, ga := range giveaways {
+			line := fmt.Sprintf("<t:%d:d> [%s](%s): ", ga.EndTime.Unix(), escapeMarkdown(ga.Title), messageLink(ga, ""))
+			switch ga.Status {
+			case models.StatusActive:
+				line += fmt.Sprintf("running, ends <t:%d:R>", ga.EndTime.Unix())
+			case models.StatusCancelled:
+				line += "cancelled"
+			default:
+				line += fmt.Sprintf("%d participants, won by %s", len(ga.Participants), mentions(ga.Excluded))
+			}
+			lines = append(lines, line)
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("History of %s (%d)", sr.Title, len(giveaways)),
+			Description: truncate(strings.Join(lines, "\n"), 4096),
+			Color:       0x00ff00,
+		})
+	case "stop":
+		id := getOption(optionMap, "id").StringValue()
+		if !db.StopSeries(id, i.GuildID) {
+			respondEphemeral(s, i, "No running recurring giveaway with that ID.")
+			return
+		}
+		sched.Cancel(seriesKey(id))
+		respondEphemeral(s, i, "Recurring giveaway stopped. Giveaways it already posted keep running.")
+	}
+}
+
+func createSeries(s *discordgo.Session, i *discordgo.InteractionCreate, optionMap map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+	schedule := strings.TrimSpace(getOption(optionMap, "schedule").StringValue())
+	rec, err := models.ParseRecurrence(schedule)
+	if err != nil {
+		respondEphemeral(s, i, "Invalid schedule: "+err.Error())
+		return
+	}
+	duration, err := models.ParseAge(getOption(optionMap, "duration").StringValue())
+	if err != nil || duration <= 0 {
+		respondEphemeral(s, i, "Invalid duration. Use e.g. 1h30m, 2d or 1w.")
+		return
+	}
+
+	now := time.Now()
+	sr := &models.Series{
+		ID:        "r" + strconv.FormatInt(now.UnixNano(), 36),
+		GuildID:   i.GuildID,
+		ChannelID: i.ChannelID,
+		HostID:    i.Member.User.ID,
+		Title:     getOption(optionMap, "title").StringValue(),
+		Schedule:  schedule,
+		Duration:  duration,
+		Winners:   1,
+		Active:    true,
+		CreatedAt: now,
+	}
+	if winnerOpt := getOption(optionMap, "winners"); winnerOpt != nil && winnerOpt.IntValue() > 0 {
+		sr.Winners = int(winnerOpt.IntValue())
+	}
+	if roleOpt := getOption(optionMap, "role"); roleOpt != nil {
+		sr.RequiredRoles = []string{roleOpt.RoleValue(nil, "").ID}
+	}
+
+	// Daily and weekly series start right away unless told otherwise, cron
+	// series at their first matching time.
+	switch firstOpt := getOption(optionMap, "first"); {
+	case firstOpt != nil:
+		sr.NextRun, err = models.ParseEndTime(firstOpt.StringValue())
+		if err != nil || !sr.NextRun.After(now) {
+			respondEphemeral(s, i, "Invalid first occurrence. Use a future duration (e.g., 2h) or date/time (YYYY-MM-DD [HH:MM]).")
+			return
+		}
+	case strings.EqualFold(schedule, "daily") || strings.EqualFold(schedule, "weekly"):
+		sr.NextRun = now
+	default:
+		sr.NextRun = rec.Next(now)
+	}
+	if rec.Next(sr.NextRun).IsZero() {
+		respondEphemeral(s, i, "That schedule never repeats.")
+		return
+	}
+
+	if err := db.SaveSeries(sr); err != nil {
+		respondEphemeral(s, i, "Could not save the recurring giveaway, please try again.")
+		return
+	}
+	ScheduleSeries(sr)
+	respondEphemeral(s, i, fmt.Sprintf("Recurring giveaway **%s** created with ID `%s`. First giveaway <t:%d:R>.",
+		escapeMarkdown(sr.Title), sr.ID, sr.NextRun.Unix()))
+}
@@ -0,0 +1,160 @@
+// internal/bot/templates.go
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+const maxTemplateName = 50
+
+// maxCommandOptions is the most options Discord allows on a command or
+// subcommand. One more and registering the commands fails.
+const maxCommandOptions = 25
+
+// templateOptions are the options of /giveaway-template save and use: the
+// template name followed by the /create-giveaway options, all optional.
+// Saving skips uploads, whose links expire, and the start time, which belongs
+// to a single giveaway rather than a template.
+func templateOptions(nameDescription string, save bool) []*discordgo.ApplicationCommandOption {
+	options := []*discordgo.ApplicationCommandOption{templateNameOption(nameDescription)}
+	for _, opt := range giveawayOptions() {
+		if save && (opt.Type == discordgo.ApplicationCommandOptionAttachment || opt.Name == "start") {
+			continue
+		}
+		opt.Required = false
+		options = append(options, opt)
+	}
+	if len(options) > maxCommandOptions {
+		panic(fmt.Sprintf("giveaway template commands have %d options, Discord allows %d", len(options), maxCommandOptions))
+	}
+	return options
+}
+
+func templateNameOption(description string) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "name",
+		Description: description,
+		Required:    true,
+		MaxLength:   maxTemplateName,
+	}
+}
+
+func giveawayTemplate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !hasPermission(s, i) {
+		respondEphemeral(s, i, "You do not have permission to use this command.")
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0]
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(sub.Options))
+	for _, opt := range sub.Options {
+		optionMap[opt.Name] = opt
+	}
+	var name string
+	if nameOpt := getOption(optionMap, "name"); nameOpt != nil {
+		name = strings.TrimSpace(nameOpt.StringValue())
+	}
+
+	switch sub.Name {
+	case "save":
+		t := &models.Template{
+			GuildID:   i.GuildID,
+			Name:      name,
+			CreatedBy: i.Member.User.ID,
+			CreatedAt: time.Now(),
+		}
+		for _, opt := range sub.Options {
+			if opt.Name == "name" {
+				continue
+			}
+			t.Options = append(t.Options, models.TemplateOption{Name: opt.Name, Type: opt.Type, Value: opt.Value})
+		}
+		if len(t.Options) == 0 {
+			respondEphemeral(s, i, "Give at least one giveaway option to save in the template.")
+			return
+		}
+		replaced, err := db.SaveTemplate(t)
+		if err != nil {
+			respondEphemeral(s, i, "Could not save the template, please try again.")
+			return
+		}
+		verb := "saved"
+		if replaced {
+			verb = "updated"
+		}
+		respondEphemeral(s, i, fmt.Sprintf("Template **%s** %s. Create a giveaway from it with /giveaway-template use.", escapeMarkdown(name), verb))
+	case "list":
+		templates := db.LoadTemplates(i.GuildID)
+		if len(templates) == 0 {
+			respondEphemeral(s, i, "No templates are saved.")
+			return
+		}
+		var lines []string
+		for _, t := range templates {
+			lines = append(lines, fmt.Sprintf("**%s**: %s", escapeMarkdown(t.Name), templateSummary(t)))
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("Giveaway Templates (%d)", len(templates)),
+			Description: truncate(strings.Join(lines, "\n"), 4096),
+			Color:       0x00ff00,
+		})
+	case "delete":
+		if !db.DeleteTemplate(i.GuildID, name) {
+			respondEphemeral(s, i, fmt.Sprintf("No template named **%s**.", escapeMarkdown(name)))
+			return
+		}
+		respondEphemeral(s, i, fmt.Sprintf("Template **%s** deleted.", escapeMarkdown(name)))
+	case "use":
+		t := db.GetTemplate(i.GuildID, name)
+		if t == nil {
+			respondEphemeral(s, i, fmt.Sprintf("No template named **%s**.", escapeMarkdown(name)))
+			return
+		}
+		// Options given with the command override the saved ones.
+		merged := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+		for _, opt := range t.Options {
+			merged[opt.Name] = &discordgo.ApplicationCommandInteractionDataOption{Name: opt.Name, Type: opt.Type, Value: opt.Value}
+		}
+		for _, opt := range sub.Options {
+			if opt.Name != "name" {
+				merged[opt.Name] = opt
+			}
+		}
+		var missing []string
+		for _, required := range []string{"title", "end"} {
+			if merged[required] == nil {
+				missing = append(missing, required)
+			}
+		}
+		if len(missing) > 0 {
+			respondEphemeral(s, i, fmt.Sprintf("Template **%s** has no %s; add it to the command.", escapeMarkdown(name), strings.Join(missing, " or ")))
+			return
+		}
+		createGiveawayFromOptions(s, i, merged)
+	}
+}
+
+// templateSummary lists the saved options of a template.
+func templateSummary(t *models.Template) string {
+	var parts []string
+	for _, opt := range t.Options {
+		var value string
+		switch opt.Type {
+		case discordgo.ApplicationCommandOptionRole:
+			value = fmt.Sprintf("<@&%v>", opt.Value)
+		case discordgo.ApplicationCommandOptionChannel:
+			value = fmt.Sprintf("<#%v>", opt.Value)
+		default:
+			value = escapeMarkdown(truncate(fmt.Sprint(opt.Value), 40))
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", opt.Name, value))
+	}
+	return strings.Join(parts, ", ")
+}
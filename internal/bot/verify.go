@@ -0,0 +1,141 @@
+// internal/bot/verify.go
+package bot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/bwmarrin/discordgo"
+)
+
+// verifyGiveaway recomputes every draw and reroll of an ended giveaway from
+// its revealed seed and the recorded participants, and compares the result
+// with the recorded winners.
+func verifyGiveaway(s *discordgo.Session, i *discordgo.InteractionCreate, giveawayID string) {
+	ga, ok := findGiveaway(giveawayID, i.GuildID)
+	if !ok {
+		respondEphemeral(s, i, "Giveaway not found.")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("Verification of %s", ga.Title),
+		Color: 0x00ff00,
+	}
+
+	if ga.Status != models.StatusEnded {
+		embed.Description = "The server seed is revealed once the giveaway ends."
+		if ga.Status == models.StatusCancelled {
+			embed.Description = "This giveaway was cancelled, so no winners were drawn."
+		}
+		if ga.Seed != "" {
+			embed.Fields = []*discordgo.MessageEmbedField{{
+				Name:  "Seed commitment (SHA-256)",
+				Value: "`" + models.SeedCommitment(ga.Seed) + "`",
+			}}
+		}
+		respondEmbed(s, i, embed)
+		return
+	}
+
+	if ga.Drop {
+		embed.Description = "This was a drop, so nothing was drawn: the first members to press 🎉 won, in this order."
+		if len(ga.Excluded) > 0 {
+			embed.Fields = []*discordgo.MessageEmbedField{{
+				Name:  "Winners",
+				Value: truncate(mentions(ga.Excluded), 1024),
+			}}
+		}
+		respondEmbed(s, i, embed)
+		return
+	}
+
+	if ga.Seed == "" {
+		embed.Description = "This giveaway was drawn before seeded draws were introduced and can't be verified."
+		respondEmbed(s, i, embed)
+		return
+	}
+	if ga.LateSeed {
+		embed.Description = "This giveaway was created before seeded draws were introduced. Its seed was only made when it was drawn and no commitment was published, so it can't be verified."
+		respondEmbed(s, i, embed)
+		return
+	}
+
+	participants := slices.Clone(ga.Participants)
+	slices.Sort(participants)
+	var sortedEntries []string
+	for _, p := range participants {
+		if n := ga.EntriesOf(p); n > 1 {
+			sortedEntries = append(sortedEntries, fmt.Sprintf("%s×%d", p, n))
+		} else {
+			sortedEntries = append(sortedEntries, p)
+		}
+	}
+	history := db.LoadWinnerHistory(ga.ID, ga.GuildID)
+
+	var lines []string
+	allMatch := true
+	var drawWinners []string
+	for _, w := range history {
+		if w.Source == models.WinSourceDraw {
+			drawWinners = append(drawWinners, w.UserID)
+		}
+	}
+	if len(drawWinners) > 0 {
+		expected := models.Draw(ga.Seed, models.DrawRound, participants, ga.Entries, nil, len(drawWinners))
+		match := slices.Equal(expected, drawWinners)
+		allMatch = allMatch && match
+		lines = append(lines, fmt.Sprintf("%s `%s`: %s", checkMark(match), models.DrawRound, mentions(expected)))
+	}
+
+	previous := drawWinners
+	for _, w := range history {
+		if w.Source != models.WinSourceReroll {
+			continue
+		}
+		round := models.RerollRound(len(previous))
+		expected := models.Draw(ga.Seed, round, participants, ga.Entries, previous, 1)
+		match := len(expected) == 1 && expected[0] == w.UserID
+		allMatch = allMatch && match
+		lines = append(lines, fmt.Sprintf("%s `%s`: %s", checkMark(match), round, mentions(expected)))
+		previous = append(previous, w.UserID)
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "No winners were drawn.")
+	}
+	if !allMatch {
+		embed.Color = 0xff0000
+	}
+
+	embed.Description = fmt.Sprintf(
+		"Winners are recomputed from the revealed seed and the %d participants sorted by ID (%d entries). "+
+			"For the k-th pick of a round, `r = uint64(SHA-256(\"<seed>:<round>:<k>\")[:8]) mod` the entries left; "+
+			"the first participant whose running entry total exceeds `r` wins and leaves the pool.\n\n%s",
+		len(participants), ga.TotalEntries(), strings.Join(lines, "\n"))
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{
+			Name:  "Server seed",
+			Value: "`" + ga.Seed + "`",
+		},
+		{
+			Name:  "Seed commitment (SHA-256)",
+			Value: "`" + models.SeedCommitment(ga.Seed) + "`",
+		},
+		{
+			Name:  "Participants (sorted)",
+			Value: truncate(strings.Join(sortedEntries, ","), 1024),
+		},
+	}
+	respondEmbed(s, i, embed)
+}
+
+func checkMark(ok bool) string {
+	if ok {
+		return "✅"
+	}
+	return "❌"
+}
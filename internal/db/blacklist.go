@@ -0,0 +1,94 @@
+// internal/db/blacklist.go
+package db
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+)
+
+func scanBlacklistEntry(row scanner) (*models.BlacklistEntry, error) {
+	var e models.BlacklistEntry
+	var addedUnix, expiresUnix int64
+	var reason, addedBy sql.NullString
+	err := row.Scan(&e.GuildID, &e.UserID, &reason, &addedBy, &addedUnix, &expiresUnix)
+	if err != nil {
+		return nil, err
+	}
+	e.Reason = reason.String
+	e.AddedBy = addedBy.String
+	e.AddedAt = time.Unix(addedUnix, 0)
+	if expiresUnix > 0 {
+		e.ExpiresAt = time.Unix(expiresUnix, 0)
+	}
+	return &e, nil
+}
+
+// GetBlacklistEntry returns the blacklist entry of a user, or nil if they are
+// not blacklisted. Expired entries are removed on the way.
+func GetBlacklistEntry(guildID string, userID string) *models.BlacklistEntry {
+	row := DB.QueryRow(`SELECT guild_id, user_id, reason, added_by, added_at, expires_at FROM blacklist WHERE guild_id = ? AND user_id = ?`, guildID, userID)
+	e, err := scanBlacklistEntry(row)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading blacklist entry:", err)
+		}
+		return nil
+	}
+	if e.Expired(time.Now()) {
+		RemoveBlacklistEntry(guildID, userID)
+		return nil
+	}
+	return e
+}
+
+func AddBlacklistEntry(e *models.BlacklistEntry) error {
+	var expires int64
+	if !e.ExpiresAt.IsZero() {
+		expires = e.ExpiresAt.Unix()
+	}
+	_, err := DB.Exec(`INSERT OR REPLACE INTO blacklist (guild_id, user_id, reason, added_by, added_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		e.GuildID, e.UserID, e.Reason, e.AddedBy, e.AddedAt.Unix(), expires)
+	return err
+}
+
+// RemoveBlacklistEntry lifts a blacklist entry. It returns false if the user
+// was not blacklisted.
+func RemoveBlacklistEntry(guildID string, userID string) bool {
+	res, err := DB.Exec(`DELETE FROM blacklist WHERE guild_id = ? AND user_id = ?`, guildID, userID)
+	if err != nil {
+		log.Println("Error removing blacklist entry:", err)
+		return false
+	}
+	n, _ := res.RowsAffected()
+	return n > 0
+}
+
+// LoadBlacklist returns the blacklist entries of a guild that have not
+// expired yet.
+func LoadBlacklist(guildID string) []*models.BlacklistEntry {
+	_, err := DB.Exec(`DELETE FROM blacklist WHERE expires_at > 0 AND expires_at <= ?`, time.Now().Unix())
+	if err != nil {
+		log.Println("Error pruning blacklist:", err)
+	}
+
+	rows, err := DB.Query(`SELECT guild_id, user_id, reason, added_by, added_at, expires_at FROM blacklist WHERE guild_id = ? ORDER BY added_at`, guildID)
+	if err != nil {
+		log.Println("Error querying blacklist:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var entries []*models.BlacklistEntry
+	for rows.Next() {
+		e, err := scanBlacklistEntry(rows)
+		if err != nil {
+			log.Println("Error scanning blacklist entry:", err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
@@ -0,0 +1,130 @@
+// internal/db/claims.go
+package db
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+)
+
+const winColumns = `giveaway_id, guild_id, user_id, won_at, source, message_id, claim_deadline, claimed_at, forfeited`
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func timeOrZero(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
+
+func scanWin(row scanner) (models.Win, error) {
+	var w models.Win
+	var source, messageID sql.NullString
+	var wonUnix, deadlineUnix, claimedUnix sql.NullInt64
+	var forfeited sql.NullBool
+	err := row.Scan(&w.GiveawayID, &w.GuildID, &w.UserID, &wonUnix, &source, &messageID, &deadlineUnix, &claimedUnix, &forfeited)
+	if err != nil {
+		return w, err
+	}
+	w.WonAt = time.Unix(wonUnix.Int64, 0)
+	w.Source = source.String
+	w.MessageID = messageID.String
+	w.ClaimDeadline = timeOrZero(deadlineUnix.Int64)
+	w.ClaimedAt = timeOrZero(claimedUnix.Int64)
+	w.Forfeited = forfeited.Bool
+	return w, nil
+}
+
+func queryWins(query string, args ...any) []models.Win {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Println("Error querying winners:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var wins []models.Win
+	for rows.Next() {
+		w, err := scanWin(rows)
+		if err != nil {
+			log.Println("Error scanning winner:", err)
+			continue
+		}
+		wins = append(wins, w)
+	}
+	return wins
+}
+
+// SetWinnerMessage links winners to the announcement carrying their Claim
+// button.
+func SetWinnerMessage(giveawayID string, guildID string, userIDs []string, messageID string) {
+	for _, uid := range userIDs {
+		_, err := DB.Exec(`UPDATE winners SET message_id = ? WHERE giveaway_id = ? AND guild_id = ? AND user_id = ?`,
+			messageID, giveawayID, guildID, uid)
+		if err != nil {
+			log.Println("Error saving winner message:", err)
+		}
+	}
+}
+
+// GetWin returns the win of a user in a giveaway, if they won it.
+func GetWin(giveawayID string, guildID string, userID string) (models.Win, bool) {
+	row := DB.QueryRow(`SELECT `+winColumns+` FROM winners WHERE giveaway_id = ? AND guild_id = ? AND user_id = ?`, giveawayID, guildID, userID)
+	w, err := scanWin(row)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading winner:", err)
+		}
+		return w, false
+	}
+	return w, true
+}
+
+// LoadAnnouncementWins returns the wins announced in one message, in the order
+// they were drawn.
+func LoadAnnouncementWins(giveawayID string, guildID string, messageID string) []models.Win {
+	return queryWins(`SELECT `+winColumns+` FROM winners WHERE giveaway_id = ? AND guild_id = ? AND message_id = ? ORDER BY won_at, rowid`,
+		giveawayID, guildID, messageID)
+}
+
+// LoadPendingClaims returns every win that still waits to be claimed, across
+// all guilds.
+func LoadPendingClaims() []models.Win {
+	return queryWins(`SELECT ` + winColumns + ` FROM winners WHERE claim_deadline > 0 AND claimed_at = 0 AND forfeited = 0`)
+}
+
+// ClaimWin marks a pending win as claimed. It returns false if the win was
+// already claimed or forfeited, or its deadline has passed even though the
+// win was not forfeited yet.
+func ClaimWin(giveawayID string, guildID string, userID string) bool {
+	now := time.Now().Unix()
+	res, err := DB.Exec(`UPDATE winners SET claimed_at = ? WHERE giveaway_id = ? AND guild_id = ? AND user_id = ? AND claim_deadline > ? AND claimed_at = 0 AND forfeited = 0`,
+		now, giveawayID, guildID, userID, now)
+	if err != nil {
+		log.Println("Error claiming win:", err)
+		return false
+	}
+	n, _ := res.RowsAffected()
+	return n > 0
+}
+
+// ForfeitWin marks a pending win as not claimed in time. It returns false if
+// the win was claimed or forfeited in the meantime.
+func ForfeitWin(giveawayID string, guildID string, userID string) bool {
+	res, err := DB.Exec(`UPDATE winners SET forfeited = 1 WHERE giveaway_id = ? AND guild_id = ? AND user_id = ? AND claim_deadline > 0 AND claimed_at = 0 AND forfeited = 0`,
+		giveawayID, guildID, userID)
+	if err != nil {
+		log.Println("Error forfeiting win:", err)
+		return false
+	}
+	n, _ := res.RowsAffected()
+	return n > 0
+}
@@ -4,6 +4,7 @@ package db
 import (
 	"database/sql"
 	"embed"
+	"fmt"
 	"log"
 	"time"
 
@@ -13,6 +14,49 @@ import (
 
 var DB *sql.DB
 
+// Columns added after the first release. schema.sql only creates missing
+// tables, so existing databases get these through ALTER TABLE on startup.
+var migrations = []struct {
+	table      string
+	column     string
+	definition string
+}{
+	{"giveaways", "status", "TEXT DEFAULT 'active'"},
+	{"giveaways", "ended_at", "INTEGER DEFAULT 0"},
+	{"giveaways", "paused", "INTEGER DEFAULT 0"},
+	{"giveaways", "remaining", "INTEGER DEFAULT 0"},
+	{"giveaways", "seed", "TEXT DEFAULT ''"},
+	{"giveaways", "bonus_roles", "TEXT DEFAULT ''"},
+	{"giveaways", "required_roles", "TEXT DEFAULT ''"},
+	{"giveaways", "role_mode", "TEXT DEFAULT 'any'"},
+	{"giveaways", "blocked_roles", "TEXT DEFAULT ''"},
+	{"giveaways", "min_account_age", "INTEGER DEFAULT 0"},
+	{"giveaways", "min_member_age", "INTEGER DEFAULT 0"},
+	{"giveaways", "claim_window", "INTEGER DEFAULT 0"},
+	{"giveaways", "host_id", "TEXT DEFAULT ''"},
+	{"giveaways", "description", "TEXT DEFAULT ''"},
+	{"giveaways", "image_url", "TEXT DEFAULT ''"},
+	{"giveaways", "thumbnail_url", "TEXT DEFAULT ''"},
+	{"giveaways", "start_time", "INTEGER DEFAULT 0"},
+	{"giveaways", "series_id", "TEXT DEFAULT ''"},
+	{"giveaways", "ping_role", "TEXT DEFAULT ''"},
+	{"giveaways", "reminders", "TEXT DEFAULT ''"},
+	{"giveaways", "reminder_message_id", "TEXT DEFAULT ''"},
+	{"giveaways", "drop_mode", "INTEGER DEFAULT 0"},
+	{"giveaways", "quiz_question", "TEXT DEFAULT ''"},
+	{"giveaways", "quiz_answers", "TEXT DEFAULT ''"},
+	{"giveaways", "quiz_ignore_case", "INTEGER DEFAULT 0"},
+	{"giveaways", "quiz_attempts", "INTEGER DEFAULT 0"},
+	{"giveaways", "late_seed", "INTEGER DEFAULT 0"},
+	{"participants", "entries", "INTEGER DEFAULT 1"},
+	{"guild_settings", "bonus_roles", "TEXT DEFAULT ''"},
+	{"guild_settings", "reminders", "TEXT DEFAULT ''"},
+	{"winners", "message_id", "TEXT DEFAULT ''"},
+	{"winners", "claim_deadline", "INTEGER DEFAULT 0"},
+	{"winners", "claimed_at", "INTEGER DEFAULT 0"},
+	{"winners", "forfeited", "INTEGER DEFAULT 0"},
+}
+
 func InitDB(path string, schema embed.FS) error {
 	var err error
 	DB, err = sql.Open("sqlite3", path)
@@ -32,10 +76,47 @@ func InitDB(path string, schema embed.FS) error {
 		return err
 	}
 
+	for _, m := range migrations {
+		if err := ensureColumn(m.table, m.column, m.definition); err != nil {
+			return err
+		}
+	}
+
 	log.Println("Database initialized successfully")
 	return nil
 }
 
+func ensureColumn(table, column, definition string) error {
+	rows, err := DB.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = DB.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		return fmt.Errorf("adding column %s.%s: %w", table, column, err)
+	}
+	log.Printf("Added column %s.%s", table, column)
+	return nil
+}
+
 func CloseDB() {
 	if DB != nil {
 		if err := DB.Close(); err != nil {
@@ -45,27 +126,42 @@ func CloseDB() {
 }
 
 func SaveGiveaway(ga *models.Giveaway) {
-	_, err := DB.Exec(`INSERT INTO giveaways (id, guild_id, title, end_time, role_id, channel_id, message_id, winners) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		ga.ID, ga.GuildID, ga.Title, ga.EndTime.Unix(), ga.RoleID, ga.ChannelID, ga.MessageID, ga.Winners)
+	_, err := DB.Exec(`INSERT INTO giveaways (id, guild_id, title, end_time, role_id, channel_id, message_id, winners, status, seed, bonus_roles, required_roles, role_mode, blocked_roles, min_account_age, min_member_age, claim_window, host_id,
+		description, image_url, thumbnail_url, start_time, series_id, ping_role, reminders, drop_mode,
+		quiz_question, quiz_answers, quiz_ignore_case, quiz_attempts) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ga.ID, ga.GuildID, ga.Title, ga.EndTime.Unix(), legacyRoleID(ga), ga.ChannelID, ga.MessageID, ga.Winners, ga.Status, ga.Seed, encodeWeights(ga.BonusRoles),
+		joinIDs(ga.RequiredRoles), ga.RoleMode, joinIDs(ga.BlockedRoles), int64(ga.MinAccountAge/time.Second), int64(ga.MinMemberAge/time.Second), int64(ga.ClaimWindow/time.Second), ga.HostID,
+		ga.Description, ga.ImageURL, ga.ThumbnailURL, unixOrZero(ga.StartTime), ga.SeriesID, ga.PingRoleID, encodeDurations(ga.Reminders), ga.Drop,
+		ga.Question, encodeAnswers(ga.Answers), ga.IgnoreCase, ga.QuizAttempts)
 	if err != nil {
 		log.Println("Error saving giveaway:", err)
 	}
 }
 
-func SaveParticipants(giveawayID string, guildID string, participants []string) {
+// UpdateGiveaway stores the editable fields of a running giveaway.
+func UpdateGiveaway(ga *models.Giveaway) {
+	_, err := DB.Exec(`UPDATE giveaways SET title = ?, end_time = ?, role_id = ?, winners = ?, required_roles = ?, role_mode = ?, blocked_roles = ?, reminders = ? WHERE id = ? AND guild_id = ?`,
+		ga.Title, ga.EndTime.Unix(), legacyRoleID(ga), ga.Winners, joinIDs(ga.RequiredRoles), ga.RoleMode, joinIDs(ga.BlockedRoles), encodeDurations(ga.Reminders),
+		ga.ID, ga.GuildID)
+	if err != nil {
+		log.Println("Error updating giveaway:", err)
+	}
+}
+
+func SaveParticipants(ga *models.Giveaway) {
 	tx, err := DB.Begin()
 	if err != nil {
 		log.Println("Error starting transaction:", err)
 		return
 	}
-	_, err = tx.Exec(`DELETE FROM participants WHERE giveaway_id = ?`, giveawayID)
+	_, err = tx.Exec(`DELETE FROM participants WHERE giveaway_id = ?`, ga.ID)
 	if err != nil {
 		tx.Rollback()
 		log.Println("Error deleting participants:", err)
 		return
 	}
-	for _, p := range participants {
-		_, err = tx.Exec(`INSERT INTO participants (giveaway_id, guild_id, user_id) VALUES (?, ?, ?)`, giveawayID, guildID, p)
+	for _, p := range ga.Participants {
+		_, err = tx.Exec(`INSERT INTO participants (giveaway_id, guild_id, user_id, entries) VALUES (?, ?, ?, ?)`, ga.ID, ga.GuildID, p, ga.EntriesOf(p))
 		if err != nil {
 			tx.Rollback()
 			log.Println("Error saving participant:", err)
@@ -77,8 +173,93 @@ func SaveParticipants(giveawayID string, guildID string, participants []string)
 	}
 }
 
+const giveawayColumns = `id, guild_id, title, end_time, role_id, channel_id, message_id, winners, status, ended_at, paused, remaining, seed, bonus_roles,
+	required_roles, role_mode, blocked_roles, min_account_age, min_member_age, claim_window, host_id,
+	description, image_url, thumbnail_url, start_time, series_id, ping_role, reminders, reminder_message_id, drop_mode,
+	quiz_question, quiz_answers, quiz_ignore_case, quiz_attempts, late_seed`
+
+// role_id holds the single required role of giveaways created before
+// required_roles existed. It is still written so the first required role shows
+// up there.
+func legacyRoleID(ga *models.Giveaway) string {
+	if len(ga.RequiredRoles) == 0 {
+		return ""
+	}
+	return ga.RequiredRoles[0]
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanGiveaway(row scanner) (*models.Giveaway, error) {
+	var id, guildID, title, roleID, channelID, messageID string
+	var status, seed, bonusRoles, requiredRoles, roleMode, blockedRoles, hostID sql.NullString
+	var description, imageURL, thumbnailURL, seriesID, pingRole, reminders, reminderMsgID sql.NullString
+	var question, answers sql.NullString
+	var endUnix, endedUnix, remaining, minAccountAge, minMemberAge, claimWindow, startUnix int64
+	var winners, quizAttempts int
+	var paused, drop, ignoreCase, lateSeed bool
+	err := row.Scan(&id, &guildID, &title, &endUnix, &roleID, &channelID, &messageID, &winners, &status, &endedUnix, &paused, &remaining, &seed, &bonusRoles,
+		&requiredRoles, &roleMode, &blockedRoles, &minAccountAge, &minMemberAge, &claimWindow, &hostID,
+		&description, &imageURL, &thumbnailURL, &startUnix, &seriesID, &pingRole, &reminders, &reminderMsgID, &drop,
+		&question, &answers, &ignoreCase, &quizAttempts, &lateSeed)
+	if err != nil {
+		return nil, err
+	}
+	ga := &models.Giveaway{
+		ID:            id,
+		GuildID:       guildID,
+		HostID:        hostID.String,
+		Title:         title,
+		Description:   description.String,
+		ImageURL:      imageURL.String,
+		ThumbnailURL:  thumbnailURL.String,
+		StartTime:     timeOrZero(startUnix),
+		SeriesID:      seriesID.String,
+		EndTime:       time.Unix(endUnix, 0),
+		RequiredRoles: splitIDs(requiredRoles.String),
+		RoleMode:      models.RoleModeAny,
+		BlockedRoles:  splitIDs(blockedRoles.String),
+		MinAccountAge: time.Duration(minAccountAge) * time.Second,
+		MinMemberAge:  time.Duration(minMemberAge) * time.Second,
+		ClaimWindow:   time.Duration(claimWindow) * time.Second,
+		Drop:          drop,
+		Question:      question.String,
+		Answers:       decodeAnswers(answers.String),
+		IgnoreCase:    ignoreCase,
+		QuizAttempts:  quizAttempts,
+		Reminders:     decodeDurations(reminders.String),
+		ReminderMsgID: reminderMsgID.String,
+		ChannelID:     channelID,
+		MessageID:     messageID,
+		PingRoleID:    pingRole.String,
+		Winners:       winners,
+		Status:        models.StatusActive,
+		Paused:        paused,
+		Remaining:     time.Duration(remaining) * time.Second,
+		Seed:          seed.String,
+		LateSeed:      lateSeed,
+		BonusRoles:    decodeWeights(bonusRoles.String),
+	}
+	if len(ga.RequiredRoles) == 0 && roleID != "" {
+		ga.RequiredRoles = []string{roleID}
+	}
+	if roleMode.String == models.RoleModeAll {
+		ga.RoleMode = models.RoleModeAll
+	}
+	if status.Valid && status.String != "" {
+		ga.Status = status.String
+	}
+	if endedUnix > 0 {
+		ga.EndedAt = time.Unix(endedUnix, 0)
+	}
+	return ga, nil
+}
+
+// LoadGiveaways returns every giveaway that has not ended yet.
 func LoadGiveaways() ([]*models.Giveaway, error) {
-	rows, err := DB.Query(`SELECT id, guild_id, title, end_time, role_id, channel_id, message_id, winners FROM giveaways`)
+	rows, err := DB.Query(`SELECT `+giveawayColumns+` FROM giveaways WHERE status = ?`, models.StatusActive)
 	if err != nil {
 		log.Println("Error querying giveaways:", err)
 		return nil, err
@@ -87,58 +268,232 @@ func LoadGiveaways() ([]*models.Giveaway, error) {
 
 	var giveaways []*models.Giveaway
 	for rows.Next() {
-		var id, guildID, title, roleID, channelID, messageID string
-		var endUnix int64
-		var winners int
-		err = rows.Scan(&id, &guildID, &title, &endUnix, &roleID, &channelID, &messageID, &winners)
+		ga, err := scanGiveaway(rows)
 		if err != nil {
 			log.Println("Error scanning giveaway:", err)
 			continue
 		}
-		ga := &models.Giveaway{
-			ID:           id,
-			GuildID:      guildID,
-			Title:        title,
-			EndTime:      time.Unix(endUnix, 0),
-			RoleID:       roleID,
-			ChannelID:    channelID,
-			MessageID:    messageID,
-			Winners:      winners,
-			Participants: LoadParticipants(id, guildID),
+		giveaways = append(giveaways, ga)
+	}
+	rows.Close()
+
+	for _, ga := range giveaways {
+		ga.Participants, ga.Entries = LoadParticipants(ga.ID, ga.GuildID)
+		ga.Excluded = LoadWinners(ga.ID, ga.GuildID)
+	}
+	return giveaways, nil
+}
+
+// LoadScheduledGiveaways returns the giveaways of every guild that wait for
+// their start time, soonest first.
+func LoadScheduledGiveaways() ([]*models.Giveaway, error) {
+	return queryScheduled(`SELECT `+giveawayColumns+` FROM giveaways WHERE status = ? ORDER BY start_time`, models.StatusScheduled)
+}
+
+// LoadGuildScheduledGiveaways returns the giveaways of a guild that wait for
+// their start time, soonest first.
+func LoadGuildScheduledGiveaways(guildID string) ([]*models.Giveaway, error) {
+	return queryScheduled(`SELECT `+giveawayColumns+` FROM giveaways WHERE status = ? AND guild_id = ? ORDER BY start_time`, models.StatusScheduled, guildID)
+}
+
+func queryScheduled(query string, args ...any) ([]*models.Giveaway, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Println("Error querying scheduled giveaways:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var giveaways []*models.Giveaway
+	for rows.Next() {
+		ga, err := scanGiveaway(rows)
+		if err != nil {
+			log.Println("Error scanning giveaway:", err)
+			continue
 		}
+		ga.Entries = map[string]int{}
 		giveaways = append(giveaways, ga)
 	}
 	return giveaways, nil
 }
 
-func LoadParticipants(giveawayID string, guildID string) []string {
-	rows, err := DB.Query(`SELECT user_id FROM participants WHERE giveaway_id = ? AND guild_id = ?`, giveawayID, guildID)
+// LoadInterruptedStarts returns the scheduled giveaways that were being
+// posted when the bot stopped. Their message may or may not have been sent.
+func LoadInterruptedStarts() ([]*models.Giveaway, error) {
+	return queryScheduled(`SELECT `+giveawayColumns+` FROM giveaways WHERE status = ?`, models.StatusStarting)
+}
+
+// ClaimScheduledGiveaway marks a scheduled giveaway as being posted. It
+// returns false if the giveaway is not scheduled anymore, so it is only ever
+// posted once.
+func ClaimScheduledGiveaway(id string, guildID string) bool {
+	res, err := DB.Exec(`UPDATE giveaways SET status = ? WHERE id = ? AND guild_id = ? AND status = ?`,
+		models.StatusStarting, id, guildID, models.StatusScheduled)
+	if err != nil {
+		log.Println("Error claiming scheduled giveaway:", err)
+		return false
+	}
+	n, _ := res.RowsAffected()
+	return n > 0
+}
+
+// StartScheduledGiveaway activates a scheduled giveaway once it is posted. The
+// giveaway takes the ID of its message, like giveaways posted right away.
+func StartScheduledGiveaway(scheduledID string, guildID string, messageID string) {
+	_, err := DB.Exec(`UPDATE giveaways SET id = ?, message_id = ?, status = ? WHERE id = ? AND guild_id = ?`,
+		messageID, messageID, models.StatusActive, scheduledID, guildID)
+	if err != nil {
+		log.Println("Error starting scheduled giveaway:", err)
+	}
+}
+
+// LoadGiveaway fetches a single giveaway regardless of its status, so ended
+// giveaways can still be rerolled or inspected after a restart.
+func LoadGiveaway(id string, guildID string) (*models.Giveaway, error) {
+	row := DB.QueryRow(`SELECT `+giveawayColumns+` FROM giveaways WHERE id = ? AND guild_id = ?`, id, guildID)
+	ga, err := scanGiveaway(row)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading giveaway:", err)
+		}
+		return nil, err
+	}
+	ga.Participants, ga.Entries = LoadParticipants(ga.ID, ga.GuildID)
+	ga.Excluded = LoadWinners(ga.ID, ga.GuildID)
+	return ga, nil
+}
+
+// LoadParticipants returns the participants of a giveaway in the order they
+// entered, and how many entries each of them has.
+func LoadParticipants(giveawayID string, guildID string) ([]string, map[string]int) {
+	rows, err := DB.Query(`SELECT user_id, entries FROM participants WHERE giveaway_id = ? AND guild_id = ? ORDER BY rowid`, giveawayID, guildID)
 	if err != nil {
 		log.Println("Error querying participants:", err)
-		return nil
+		return nil, nil
 	}
 	defer rows.Close()
 
 	var participants []string
+	entries := make(map[string]int)
 	for rows.Next() {
 		var userID string
-		err = rows.Scan(&userID)
+		var n sql.NullInt64
+		err = rows.Scan(&userID, &n)
 		if err != nil {
 			log.Println("Error scanning participant:", err)
 			continue
 		}
 		participants = append(participants, userID)
+		if n.Int64 > 1 {
+			entries[userID] = int(n.Int64)
+		}
 	}
-	return participants
+	return participants, entries
 }
 
-func DeleteGiveaway(id string, guildID string) {
-	_, err := DB.Exec(`DELETE FROM giveaways WHERE id = ? AND guild_id = ?`, id, guildID)
+// LoadWinners returns everyone who has won a giveaway, in the order they were
+// drawn, including rerolled winners.
+func LoadWinners(giveawayID string, guildID string) []string {
+	rows, err := DB.Query(`SELECT user_id FROM winners WHERE giveaway_id = ? AND guild_id = ? ORDER BY won_at, rowid`, giveawayID, guildID)
 	if err != nil {
-		log.Println("Error deleting giveaway:", err)
+		log.Println("Error querying winners:", err)
+		return nil
 	}
-	_, err = DB.Exec(`DELETE FROM participants WHERE giveaway_id = ? AND guild_id = ?`, id, guildID)
+	defer rows.Close()
+
+	var winners []string
+	for rows.Next() {
+		var userID string
+		err = rows.Scan(&userID)
+		if err != nil {
+			log.Println("Error scanning winner:", err)
+			continue
+		}
+		winners = append(winners, userID)
+	}
+	return winners
+}
+
+// LoadWinnerHistory returns every win of a giveaway in the order they were
+// drawn, with how each winner was picked.
+func LoadWinnerHistory(giveawayID string, guildID string) []models.Win {
+	return queryWins(`SELECT `+winColumns+` FROM winners WHERE giveaway_id = ? AND guild_id = ? ORDER BY won_at, rowid`, giveawayID, guildID)
+}
+
+// SetGiveawaySeed stores a server seed made at draw time for a giveaway
+// created before draws were seeded.
+func SetGiveawaySeed(id string, guildID string, seed string) {
+	_, err := DB.Exec(`UPDATE giveaways SET seed = ?, late_seed = 1 WHERE id = ? AND guild_id = ?`, seed, id, guildID)
 	if err != nil {
-		log.Println("Error deleting participants:", err)
+		log.Println("Error saving giveaway seed:", err)
+	}
+}
+
+// SaveWinners records the result of a draw or reroll. source is one of the
+// models.WinSource* constants. Winners of giveaways with a claim window get
+// claimDeadline, otherwise it is the zero time.
+func SaveWinners(giveawayID string, guildID string, userIDs []string, source string, claimDeadline time.Time) {
+	now := time.Now().Unix()
+	for _, uid := range userIDs {
+		_, err := DB.Exec(`INSERT OR REPLACE INTO winners (giveaway_id, guild_id, user_id, won_at, source, claim_deadline) VALUES (?, ?, ?, ?, ?, ?)`,
+			giveawayID, guildID, uid, now, source, unixOrZero(claimDeadline))
+		if err != nil {
+			log.Println("Error saving winner:", err)
+		}
+	}
+}
+
+// SetGiveawayPaused stores whether a giveaway is paused and how much time it
+// had left, so it can be resumed after a restart.
+func SetGiveawayPaused(id string, guildID string, paused bool, remaining time.Duration) {
+	_, err := DB.Exec(`UPDATE giveaways SET paused = ?, remaining = ? WHERE id = ? AND guild_id = ?`,
+		paused, int64(remaining/time.Second), id, guildID)
+	if err != nil {
+		log.Println("Error updating paused state:", err)
+	}
+}
+
+// SetGiveawayStatus moves a giveaway to a new state. Giveaways leaving the
+// active state get their ended_at timestamp set.
+func SetGiveawayStatus(id string, guildID string, status string) {
+	var endedAt int64
+	if status != models.StatusActive {
+		endedAt = time.Now().Unix()
+	}
+	_, err := DB.Exec(`UPDATE giveaways SET status = ?, ended_at = ? WHERE id = ? AND guild_id = ?`, status, endedAt, id, guildID)
+	if err != nil {
+		log.Println("Error updating giveaway status:", err)
+	}
+}
+
+// AddRemovedParticipant keeps a user a moderator removed from entering the
+// giveaway again.
+func AddRemovedParticipant(giveawayID string, guildID string, userID string, removedBy string) {
+	_, err := DB.Exec(`INSERT OR REPLACE INTO removed_participants (giveaway_id, guild_id, user_id, removed_by, removed_at) VALUES (?, ?, ?, ?, ?)`,
+		giveawayID, guildID, userID, removedBy, time.Now().Unix())
+	if err != nil {
+		log.Println("Error saving removed participant:", err)
+	}
+}
+
+func IsRemovedParticipant(giveawayID string, guildID string, userID string) bool {
+	var n int
+	err := DB.QueryRow(`SELECT COUNT(*) FROM removed_participants WHERE giveaway_id = ? AND guild_id = ? AND user_id = ?`, giveawayID, guildID, userID).Scan(&n)
+	if err != nil {
+		log.Println("Error checking removed participant:", err)
+		return false
+	}
+	return n > 0
+}
+
+// DeleteRemovedParticipant lifts a removal. It returns false if the user was
+// not removed.
+func DeleteRemovedParticipant(giveawayID string, guildID string, userID string) bool {
+	res, err := DB.Exec(`DELETE FROM removed_participants WHERE giveaway_id = ? AND guild_id = ? AND user_id = ?`, giveawayID, guildID, userID)
+	if err != nil {
+		log.Println("Error deleting removed participant:", err)
+		return false
 	}
+	n, _ := res.RowsAffected()
+	return n > 0
 }
@@ -0,0 +1,74 @@
+// internal/db/quiz.go
+package db
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+)
+
+// Quiz answers may contain commas, so they are stored one per line.
+func encodeAnswers(answers []string) string {
+	return strings.Join(answers, "\n")
+}
+
+func decodeAnswers(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// RecordQuizAnswer stores an attempt at the question of a quiz giveaway.
+func RecordQuizAnswer(a models.QuizAnswer) {
+	_, err := DB.Exec(`INSERT INTO quiz_answers (giveaway_id, guild_id, user_id, answer, correct, answered_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		a.GiveawayID, a.GuildID, a.UserID, a.Answer, a.Correct, a.AnsweredAt.Unix())
+	if err != nil {
+		log.Println("Error saving quiz answer:", err)
+	}
+}
+
+// QuizAttempts returns how often a user answered the question of a giveaway
+// and whether one of the answers was correct.
+func QuizAttempts(giveawayID string, guildID string, userID string) (int, bool) {
+	var attempts int
+	var correct bool
+	err := DB.QueryRow(`SELECT COUNT(*), COALESCE(MAX(correct), 0) FROM quiz_answers WHERE giveaway_id = ? AND guild_id = ? AND user_id = ?`,
+		giveawayID, guildID, userID).Scan(&attempts, &correct)
+	if err != nil {
+		log.Println("Error counting quiz attempts:", err)
+	}
+	return attempts, correct
+}
+
+// LoadQuizAnswers returns the answers given to a giveaway's question, oldest
+// first. An empty userID returns the answers of every user.
+func LoadQuizAnswers(giveawayID string, guildID string, userID string) []models.QuizAnswer {
+	query := `SELECT user_id, answer, correct, answered_at FROM quiz_answers WHERE giveaway_id = ? AND guild_id = ?`
+	args := []any{giveawayID, guildID}
+	if userID != "" {
+		query += ` AND user_id = ?`
+		args = append(args, userID)
+	}
+	rows, err := DB.Query(query+` ORDER BY answered_at, rowid`, args...)
+	if err != nil {
+		log.Println("Error querying quiz answers:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var answers []models.QuizAnswer
+	for rows.Next() {
+		a := models.QuizAnswer{GiveawayID: giveawayID, GuildID: guildID}
+		var answeredAt int64
+		if err := rows.Scan(&a.UserID, &a.Answer, &a.Correct, &answeredAt); err != nil {
+			log.Println("Error scanning quiz answer:", err)
+			continue
+		}
+		a.AnsweredAt = time.Unix(answeredAt, 0)
+		answers = append(answers, a)
+	}
+	return answers
+}
@@ -0,0 +1,55 @@
+// internal/db/reminders.go
+package db
+
+import (
+	"log"
+)
+
+// SetReminderMessage remembers the reminder message of a giveaway so later
+// reminders edit it instead of posting again.
+func SetReminderMessage(giveawayID string, guildID string, messageID string) {
+	_, err := DB.Exec(`UPDATE giveaways SET reminder_message_id = ? WHERE id = ? AND guild_id = ?`, messageID, giveawayID, guildID)
+	if err != nil {
+		log.Println("Error saving reminder message:", err)
+	}
+}
+
+// ToggleReminderOptIn switches whether a user gets reminder DMs for a
+// giveaway and returns the new state.
+func ToggleReminderOptIn(giveawayID string, guildID string, userID string) (bool, error) {
+	res, err := DB.Exec(`DELETE FROM reminder_optins WHERE giveaway_id = ? AND guild_id = ? AND user_id = ?`, giveawayID, guildID, userID)
+	if err != nil {
+		log.Println("Error updating reminder opt-in:", err)
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return false, nil
+	}
+	_, err = DB.Exec(`INSERT INTO reminder_optins (giveaway_id, guild_id, user_id) VALUES (?, ?, ?)`, giveawayID, guildID, userID)
+	if err != nil {
+		log.Println("Error saving reminder opt-in:", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// LoadReminderOptIns returns who asked for reminder DMs for a giveaway.
+func LoadReminderOptIns(giveawayID string, guildID string) []string {
+	rows, err := DB.Query(`SELECT user_id FROM reminder_optins WHERE giveaway_id = ? AND guild_id = ?`, giveawayID, guildID)
+	if err != nil {
+		log.Println("Error querying reminder opt-ins:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			log.Println("Error scanning reminder opt-in:", err)
+			continue
+		}
+		users = append(users, userID)
+	}
+	return users
+}
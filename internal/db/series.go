@@ -0,0 +1,131 @@
+// internal/db/series.go
+package db
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+)
+
+const seriesColumns = `id, guild_id, channel_id, host_id, title, schedule, duration, winners, required_roles, next_run, occurrences, active, created_at`
+
+func scanSeries(row scanner) (*models.Series, error) {
+	var sr models.Series
+	var hostID, requiredRoles sql.NullString
+	var duration, nextUnix, createdUnix int64
+	err := row.Scan(&sr.ID, &sr.GuildID, &sr.ChannelID, &hostID, &sr.Title, &sr.Schedule, &duration, &sr.Winners, &requiredRoles,
+		&nextUnix, &sr.Occurrences, &sr.Active, &createdUnix)
+	if err != nil {
+		return nil, err
+	}
+	sr.HostID = hostID.String
+	sr.RequiredRoles = splitIDs(requiredRoles.String)
+	sr.Duration = time.Duration(duration) * time.Second
+	sr.NextRun = time.Unix(nextUnix, 0)
+	sr.CreatedAt = time.Unix(createdUnix, 0)
+	return &sr, nil
+}
+
+func querySeries(query string, args ...any) []*models.Series {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		log.Println("Error querying giveaway series:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var series []*models.Series
+	for rows.Next() {
+		sr, err := scanSeries(rows)
+		if err != nil {
+			log.Println("Error scanning giveaway series:", err)
+			continue
+		}
+		series = append(series, sr)
+	}
+	return series
+}
+
+func SaveSeries(sr *models.Series) error {
+	_, err := DB.Exec(`INSERT INTO giveaway_series (`+seriesColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		sr.ID, sr.GuildID, sr.ChannelID, sr.HostID, sr.Title, sr.Schedule, int64(sr.Duration/time.Second), sr.Winners, joinIDs(sr.RequiredRoles),
+		sr.NextRun.Unix(), sr.Occurrences, sr.Active, sr.CreatedAt.Unix())
+	if err != nil {
+		log.Println("Error saving giveaway series:", err)
+	}
+	return err
+}
+
+// GetSeries returns a series of a guild, or nil if there is none with that ID.
+func GetSeries(id string, guildID string) *models.Series {
+	row := DB.QueryRow(`SELECT `+seriesColumns+` FROM giveaway_series WHERE id = ? AND guild_id = ?`, id, guildID)
+	sr, err := scanSeries(row)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading giveaway series:", err)
+		}
+		return nil
+	}
+	return sr
+}
+
+// LoadActiveSeries returns the running series of every guild.
+func LoadActiveSeries() []*models.Series {
+	return querySeries(`SELECT ` + seriesColumns + ` FROM giveaway_series WHERE active = 1`)
+}
+
+// LoadGuildSeries returns every series of a guild, running ones first.
+func LoadGuildSeries(guildID string) []*models.Series {
+	return querySeries(`SELECT `+seriesColumns+` FROM giveaway_series WHERE guild_id = ? ORDER BY active DESC, next_run`, guildID)
+}
+
+// SetSeriesRun stores how many occurrences a series has posted and when the
+// next one is due.
+func SetSeriesRun(id string, occurrences int, nextRun time.Time) {
+	_, err := DB.Exec(`UPDATE giveaway_series SET occurrences = ?, next_run = ? WHERE id = ?`, occurrences, nextRun.Unix(), id)
+	if err != nil {
+		log.Println("Error updating giveaway series:", err)
+	}
+}
+
+// StopSeries keeps a series from posting again. It returns false if the
+// series was not running.
+func StopSeries(id string, guildID string) bool {
+	res, err := DB.Exec(`UPDATE giveaway_series SET active = 0 WHERE id = ? AND guild_id = ? AND active = 1`, id, guildID)
+	if err != nil {
+		log.Println("Error stopping giveaway series:", err)
+		return false
+	}
+	n, _ := res.RowsAffected()
+	return n > 0
+}
+
+// LoadSeriesGiveaways returns the giveaways posted for a series, newest first,
+// with their winners.
+func LoadSeriesGiveaways(seriesID string, guildID string) []*models.Giveaway {
+	rows, err := DB.Query(`SELECT `+giveawayColumns+` FROM giveaways WHERE series_id = ? AND guild_id = ? ORDER BY end_time DESC`, seriesID, guildID)
+	if err != nil {
+		log.Println("Error querying series giveaways:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var giveaways []*models.Giveaway
+	for rows.Next() {
+		ga, err := scanGiveaway(rows)
+		if err != nil {
+			log.Println("Error scanning giveaway:", err)
+			continue
+		}
+		giveaways = append(giveaways, ga)
+	}
+	rows.Close()
+
+	for _, ga := range giveaways {
+		ga.Participants, ga.Entries = LoadParticipants(ga.ID, ga.GuildID)
+		ga.Excluded = LoadWinners(ga.ID, ga.GuildID)
+	}
+	return giveaways
+}
@@ -0,0 +1,134 @@
+// internal/db/settings.go
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lists of Discord IDs are stored as comma separated text.
+func joinIDs(ids []string) string {
+	return strings.Join(ids, ",")
+}
+
+func splitIDs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// Role multipliers are stored as "roleID:entries" pairs, comma separated.
+func encodeWeights(weights map[string]int) string {
+	pairs := make([]string, 0, len(weights))
+	for id, n := range weights {
+		pairs = append(pairs, fmt.Sprintf("%s:%d", id, n))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func decodeWeights(s string) map[string]int {
+	weights := make(map[string]int)
+	for _, pair := range splitIDs(s) {
+		id, n, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Atoi(n); err == nil {
+			weights[id] = v
+		}
+	}
+	return weights
+}
+
+// Durations are stored as comma separated seconds.
+func encodeDurations(ds []time.Duration) string {
+	parts := make([]string, len(ds))
+	for k, d := range ds {
+		parts[k] = strconv.FormatInt(int64(d/time.Second), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+func decodeDurations(s string) []time.Duration {
+	var ds []time.Duration
+	for _, part := range splitIDs(s) {
+		if n, err := strconv.ParseInt(part, 10, 64); err == nil {
+			ds = append(ds, time.Duration(n)*time.Second)
+		}
+	}
+	return ds
+}
+
+func ensureGuildSettings(guildID string) error {
+	_, err := DB.Exec(`INSERT OR IGNORE INTO guild_settings (guild_id) VALUES (?)`, guildID)
+	return err
+}
+
+func GetManagerRoles(guildID string) []string {
+	var roles sql.NullString
+	err := DB.QueryRow(`SELECT manager_roles FROM guild_settings WHERE guild_id = ?`, guildID).Scan(&roles)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading manager roles:", err)
+		}
+		return nil
+	}
+	return splitIDs(roles.String)
+}
+
+func SetManagerRoles(guildID string, roles []string) error {
+	if err := ensureGuildSettings(guildID); err != nil {
+		return err
+	}
+	_, err := DB.Exec(`UPDATE guild_settings SET manager_roles = ? WHERE guild_id = ?`, joinIDs(roles), guildID)
+	return err
+}
+
+// GetBonusRoles returns the default role entry multipliers of a guild.
+func GetBonusRoles(guildID string) map[string]int {
+	var weights sql.NullString
+	err := DB.QueryRow(`SELECT bonus_roles FROM guild_settings WHERE guild_id = ?`, guildID).Scan(&weights)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading bonus roles:", err)
+		}
+		return map[string]int{}
+	}
+	return decodeWeights(weights.String)
+}
+
+func SetBonusRoles(guildID string, weights map[string]int) error {
+	if err := ensureGuildSettings(guildID); err != nil {
+		return err
+	}
+	_, err := DB.Exec(`UPDATE guild_settings SET bonus_roles = ? WHERE guild_id = ?`, encodeWeights(weights), guildID)
+	return err
+}
+
+// GetReminders returns the default reminder offsets of a guild.
+func GetReminders(guildID string) []time.Duration {
+	var reminders sql.NullString
+	err := DB.QueryRow(`SELECT reminders FROM guild_settings WHERE guild_id = ?`, guildID).Scan(&reminders)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading reminders:", err)
+		}
+		return nil
+	}
+	return decodeDurations(reminders.String)
+}
+
+func SetReminders(guildID string, offsets []time.Duration) error {
+	if err := ensureGuildSettings(guildID); err != nil {
+		return err
+	}
+	_, err := DB.Exec(`UPDATE guild_settings SET reminders = ? WHERE guild_id = ?`, encodeDurations(offsets), guildID)
+	return err
+}
@@ -0,0 +1,92 @@
+// internal/db/templates.go
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+)
+
+func scanTemplate(row scanner) (*models.Template, error) {
+	var t models.Template
+	var options string
+	var createdBy sql.NullString
+	var createdUnix int64
+	err := row.Scan(&t.GuildID, &t.Name, &options, &createdBy, &createdUnix)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(options), &t.Options); err != nil {
+		return nil, err
+	}
+	t.CreatedBy = createdBy.String
+	t.CreatedAt = time.Unix(createdUnix, 0)
+	return &t, nil
+}
+
+// SaveTemplate stores a template, replacing one with the same name. It
+// returns whether a template was replaced.
+func SaveTemplate(t *models.Template) (bool, error) {
+	options, err := json.Marshal(t.Options)
+	if err != nil {
+		return false, err
+	}
+	replaced := GetTemplate(t.GuildID, t.Name) != nil
+	_, err = DB.Exec(`INSERT OR REPLACE INTO giveaway_templates (guild_id, name, options, created_by, created_at) VALUES (?, ?, ?, ?, ?)`,
+		t.GuildID, t.Name, string(options), t.CreatedBy, t.CreatedAt.Unix())
+	if err != nil {
+		log.Println("Error saving template:", err)
+		return false, err
+	}
+	return replaced, nil
+}
+
+// GetTemplate returns a template of a guild, or nil if there is none with
+// that name.
+func GetTemplate(guildID string, name string) *models.Template {
+	row := DB.QueryRow(`SELECT guild_id, name, options, created_by, created_at FROM giveaway_templates WHERE guild_id = ? AND name = ?`, guildID, name)
+	t, err := scanTemplate(row)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error loading template:", err)
+		}
+		return nil
+	}
+	return t
+}
+
+// LoadTemplates returns the templates of a guild by name.
+func LoadTemplates(guildID string) []*models.Template {
+	rows, err := DB.Query(`SELECT guild_id, name, options, created_by, created_at FROM giveaway_templates WHERE guild_id = ? ORDER BY name`, guildID)
+	if err != nil {
+		log.Println("Error querying templates:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var templates []*models.Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			log.Println("Error scanning template:", err)
+			continue
+		}
+		templates = append(templates, t)
+	}
+	return templates
+}
+
+// DeleteTemplate removes a template. It returns false if there was none with
+// that name.
+func DeleteTemplate(guildID string, name string) bool {
+	res, err := DB.Exec(`DELETE FROM giveaway_templates WHERE guild_id = ? AND name = ?`, guildID, name)
+	if err != nil {
+		log.Println("Error deleting template:", err)
+		return false
+	}
+	n, _ := res.RowsAffected()
+	return n > 0
+}
@@ -0,0 +1,18 @@
+// internal/models/blacklist.go
+package models
+
+import "time"
+
+// BlacklistEntry keeps a user out of every giveaway in a guild.
+type BlacklistEntry struct {
+	GuildID   string
+	UserID    string
+	Reason    string
+	AddedBy   string
+	AddedAt   time.Time
+	ExpiresAt time.Time // zero for permanent entries
+}
+
+func (e *BlacklistEntry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
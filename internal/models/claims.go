@@ -0,0 +1,77 @@
+// internal/models/claims.go
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ClaimsFieldName is the name of the embed field listing claim status on a
+// winner announcement.
+const ClaimsFieldName = "Claims"
+
+// ClaimDeadline returns until when winners drawn at the given time can claim
+// their prize, or the zero time if the giveaway has no claim window.
+func (ga *Giveaway) ClaimDeadline(drawnAt time.Time) time.Time {
+	if ga.ClaimWindow <= 0 {
+		return time.Time{}
+	}
+	return drawnAt.Add(ga.ClaimWindow)
+}
+
+// Pending reports whether a win can still be claimed.
+func (w *Win) Pending() bool {
+	return !w.ClaimDeadline.IsZero() && w.ClaimedAt.IsZero() && !w.Forfeited
+}
+
+// ClaimsField lists whether each winner of an announcement has claimed.
+func ClaimsField(wins []Win) *discordgo.MessageEmbedField {
+	var lines []string
+	for _, w := range wins {
+		switch {
+		case !w.ClaimedAt.IsZero():
+			lines = append(lines, fmt.Sprintf("✅ <@%s> claimed <t:%d:R>", w.UserID, w.ClaimedAt.Unix()))
+		case w.Forfeited:
+			lines = append(lines, fmt.Sprintf("❌ <@%s> did not claim in time", w.UserID))
+		default:
+			lines = append(lines, fmt.Sprintf("⏳ <@%s> must claim <t:%d:R>", w.UserID, w.ClaimDeadline.Unix()))
+		}
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  ClaimsFieldName,
+		Value: strings.Join(lines, "\n"),
+	}
+}
+
+// WinnerComponents are the buttons under a winner announcement. The Claim
+// button only shows on giveaways with a claim window.
+func WinnerComponents(ga *Giveaway, claimOpen bool) []discordgo.MessageComponent {
+	buttons := []discordgo.MessageComponent{
+		discordgo.Button{
+			Label: "Original message",
+			Style: discordgo.LinkButton,
+			URL:   fmt.Sprintf("https://discord.com/channels/%v/%v/%v", ga.GuildID, ga.ChannelID, ga.MessageID),
+		},
+		discordgo.Button{
+			Label:    "Reroll",
+			Style:    discordgo.PrimaryButton,
+			CustomID: "reroll_" + ga.ID,
+			Disabled: len(ga.Participants) <= len(ga.Excluded),
+		},
+	}
+	if ga.ClaimWindow > 0 {
+		buttons = append(buttons, discordgo.Button{
+			Label:    "Claim",
+			Emoji:    &discordgo.ComponentEmoji{Name: "🎁"},
+			Style:    discordgo.SuccessButton,
+			CustomID: "claim_" + ga.ID,
+			Disabled: !claimOpen,
+		})
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
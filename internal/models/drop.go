@@ -0,0 +1,80 @@
+// internal/models/drop.go
+package models
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// In a drop the first Winners eligible members to press 🎉 win right away.
+// Their IDs are kept in Excluded in the order they claimed, like drawn winners.
+
+// DropSlotsLeft returns how many members can still win a drop.
+func (ga *Giveaway) DropSlotsLeft() int {
+	return max(ga.Winners-len(ga.Excluded), 0)
+}
+
+func dropHeader(ga *Giveaway, timestamp string) string {
+	var header string
+	switch {
+	case ga.Status == StatusActive:
+		header = fmt.Sprintf("Press 🎉 to claim! The first **%d** eligible members win instantly.\n", ga.Winners)
+	case ga.DropSlotsLeft() == 0:
+		header = fmt.Sprintf("**All %d slots have been claimed!**\n", ga.Winners)
+	default:
+		header = "**This drop has ended.**\n"
+	}
+	header += fmt.Sprintf("Slots claimed: **%d/%d**\n", len(ga.Excluded), ga.Winners)
+	if len(ga.Excluded) > 0 {
+		var mentions []string
+		for _, uid := range ga.Excluded {
+			mentions = append(mentions, "<@"+uid+">")
+		}
+		header += "Claimed by: " + strings.Join(mentions, ", ") + "\n"
+	}
+	header += hostedByLine(ga)
+	if ga.Status == StatusActive {
+		header += "Ends: " + timestamp + "\n"
+	}
+	return header + "\n"
+}
+
+// CloseDrop shows the final claims of a drop and disables its entry button.
+func CloseDrop(s *discordgo.Session, ga *Giveaway) {
+	embed := CreateGiveawayEmbed(ga)
+	embed.Color = 0xffd700
+	embed.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: "Ended at"}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Emoji:    &discordgo.ComponentEmoji{Name: "🎉"},
+					Style:    discordgo.PrimaryButton,
+					CustomID: "enter_giveaway",
+					Disabled: true,
+				},
+				discordgo.Button{
+					Label:    "Participants",
+					Style:    discordgo.SecondaryButton,
+					CustomID: "list_participants_1",
+				},
+			},
+		},
+	}
+
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         ga.MessageID,
+		Channel:    ga.ChannelID,
+		Embed:      embed,
+		Components: &components,
+	})
+	if err != nil {
+		log.Printf("Error closing drop message %s in channel %s: %v", ga.MessageID, ga.ChannelID, err)
+	}
+}
@@ -0,0 +1,84 @@
+// internal/models/fairness.go
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"slices"
+)
+
+// Draws are provably fair through commit–reveal. When a giveaway is created
+// the bot picks a random server seed and only publishes its SHA-256 hash, the
+// commitment. Once the giveaway ends the seed is revealed, so anyone can check
+// it against the commitment and recompute every winner with Draw.
+
+// Name of the first draw of a giveaway. Rerolls use RerollRound.
+const DrawRound = "draw"
+
+// NewSeed returns a fresh random server seed.
+func NewSeed() string {
+	b := make([]byte, 32)
+	// crypto/rand.Read never returns an error.
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SeedCommitment is the hash of a seed that is published before the draw.
+func SeedCommitment(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// RerollRound names the reroll that picks the next winner after previous
+// winners have already been drawn.
+func RerollRound(previous int) string {
+	return fmt.Sprintf("reroll-%d", previous)
+}
+
+// Draw picks up to count winners, weighted by entries and without
+// replacement. Participants are sorted and those in exclude are dropped. For
+// the k-th winner (counting from 0), r = uint64(SHA-256("<seed>:<round>:<k>")[:8])
+// mod the total entries left in the pool; walking the pool in order, the
+// first participant whose running entry total exceeds r wins and is removed.
+// With one entry each this is simply index r of the pool.
+func Draw(seed string, round string, participants []string, entries map[string]int, exclude []string, count int) []string {
+	pool := make([]string, 0, len(participants))
+	for _, p := range participants {
+		if !slices.Contains(exclude, p) {
+			pool = append(pool, p)
+		}
+	}
+	slices.Sort(pool)
+	pool = slices.Compact(pool)
+
+	weight := func(p string) uint64 {
+		if n := entries[p]; n > 1 {
+			return uint64(n)
+		}
+		return 1
+	}
+
+	var winners []string
+	for k := 0; k < count && len(pool) > 0; k++ {
+		var total uint64
+		for _, p := range pool {
+			total += weight(p)
+		}
+		sum := sha256.Sum256(fmt.Appendf(nil, "%s:%s:%d", seed, round, k))
+		r := binary.BigEndian.Uint64(sum[:8]) % total
+
+		idx := 0
+		for acc := uint64(0); idx < len(pool); idx++ {
+			acc += weight(pool[idx])
+			if r < acc {
+				break
+			}
+		}
+		winners = append(winners, pool[idx])
+		pool = slices.Delete(pool, idx, idx+1)
+	}
+	return winners
+}
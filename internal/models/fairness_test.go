@@ -0,0 +1,153 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// The expected winners below were computed independently from the algorithm
+// described on Draw. If one of these changes, every past /verify-giveaway
+// result changes with it.
+func TestDrawKnownResults(t *testing.T) {
+	participants := []string{"u3", "u1", "u2", "u4", "u5"}
+	tests := []struct {
+		name         string
+		seed         string
+		round        string
+		participants []string
+		entries      map[string]int
+		exclude      []string
+		count        int
+		want         []string
+	}{
+		{
+			name:         "equal entries",
+			seed:         "seed",
+			round:        DrawRound,
+			participants: participants,
+			count:        3,
+			want:         []string{"u4", "u3", "u1"},
+		},
+		{
+			name:         "weighted entries",
+			seed:         "seed",
+			round:        DrawRound,
+			participants: []string{"alice", "bob", "carol"},
+			entries:      map[string]int{"alice": 5, "carol": 3},
+			count:        3,
+			want:         []string{"carol", "alice", "bob"},
+		},
+		{
+			name:         "reroll excludes previous winners",
+			seed:         "seed",
+			round:        RerollRound(2),
+			participants: participants,
+			exclude:      []string{"u1", "u4"},
+			count:        1,
+			want:         []string{"u2"},
+		},
+		{
+			name:         "more winners than participants",
+			seed:         "0f1e2d3c",
+			round:        DrawRound,
+			participants: participants,
+			entries:      map[string]int{"u2": 10},
+			count:        10,
+			want:         []string{"u2", "u3", "u4", "u5", "u1"},
+		},
+		{
+			name:  "no participants",
+			seed:  "seed",
+			round: DrawRound,
+			count: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Draw(tt.seed, tt.round, tt.participants, tt.entries, tt.exclude, tt.count)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Draw = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrawIgnoresParticipantOrderAndDuplicates(t *testing.T) {
+	want := Draw("seed", DrawRound, []string{"u1", "u2", "u3", "u4", "u5"}, nil, nil, 3)
+	for _, participants := range [][]string{
+		{"u5", "u4", "u3", "u2", "u1"},
+		{"u3", "u1", "u5", "u2", "u4"},
+		{"u1", "u2", "u2", "u3", "u4", "u5", "u1"},
+	} {
+		if got := Draw("seed", DrawRound, participants, nil, nil, 3); !slices.Equal(got, want) {
+			t.Errorf("Draw(%v) = %v, want %v", participants, got, want)
+		}
+	}
+}
+
+func TestDrawDependsOnSeedAndRound(t *testing.T) {
+	participants := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	base := Draw("seed", DrawRound, participants, nil, nil, len(participants))
+	if other := Draw("other seed", DrawRound, participants, nil, nil, len(participants)); slices.Equal(other, base) {
+		t.Error("a different seed drew the same order")
+	}
+	if other := Draw("seed", RerollRound(0), participants, nil, nil, len(participants)); slices.Equal(other, base) {
+		t.Error("a different round drew the same order")
+	}
+}
+
+func TestDrawWithoutReplacement(t *testing.T) {
+	participants := []string{"a", "b", "c", "d"}
+	entries := map[string]int{"a": 100, "b": 2}
+	for i := range 50 {
+		got := Draw(fmt.Sprintf("seed-%d", i), DrawRound, participants, entries, nil, len(participants))
+		sorted := slices.Sorted(slices.Values(got))
+		if !slices.Equal(sorted, participants) {
+			t.Fatalf("seed-%d drew %v, want every participant exactly once", i, got)
+		}
+	}
+}
+
+func TestDrawWeighting(t *testing.T) {
+	// a has 9 of 10 entries, so it should win about 90% of draws.
+	wins := 0
+	for i := range 1000 {
+		if Draw(fmt.Sprintf("s%d", i), DrawRound, []string{"a", "b"}, map[string]int{"a": 9}, nil, 1)[0] == "a" {
+			wins++
+		}
+	}
+	if wins != 905 {
+		t.Errorf("a won %d of 1000 draws, want 905", wins)
+	}
+}
+
+func TestSeedCommitment(t *testing.T) {
+	const want = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := SeedCommitment("abc"); got != want {
+		t.Errorf("SeedCommitment(abc) = %s, want %s", got, want)
+	}
+	seed := NewSeed()
+	if len(seed) != 64 || seed == NewSeed() {
+		t.Errorf("NewSeed returned %q", seed)
+	}
+}
+
+func TestRerollRound(t *testing.T) {
+	tests := []struct {
+		previous int
+		want     string
+	}{
+		{0, "reroll-0"},
+		{1, "reroll-1"},
+		{12, "reroll-12"},
+	}
+	for _, tt := range tests {
+		if got := RerollRound(tt.previous); got != tt.want {
+			t.Errorf("RerollRound(%d) = %q, want %q", tt.previous, got, tt.want)
+		}
+	}
+	if DrawRound != "draw" {
+		t.Errorf("DrawRound = %q, want draw", DrawRound)
+	}
+}
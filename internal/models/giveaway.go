@@ -4,7 +4,9 @@ package models
 import (
 	"fmt"
 	"log"
-	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,32 +15,197 @@ import (
 )
 
 type Giveaway struct {
-	ID           string
-	GuildID      string
-	Title        string
-	EndTime      time.Time
-	RoleID       string
-	Participants []string
-	Excluded     []string
-	ChannelID    string
-	MessageID    string
-	Timer        *time.Timer
-	Winners      int
+	ID            string
+	GuildID       string
+	HostID        string // user who created the giveaway, empty for old giveaways
+	Title         string
+	Description   string          // longer prize description shown above the entry info
+	ImageURL      string          // attachment:// for an image uploaded with the giveaway message
+	ImageUpload   *discordgo.File // upload to send with the giveaway message, not stored
+	ThumbnailURL  string
+	StartTime     time.Time // when a scheduled giveaway gets posted
+	SeriesID      string    // recurring series the giveaway was posted for
+	EndTime       time.Time
+	RequiredRoles []string
+	RoleMode      string // RoleModeAny or RoleModeAll for RequiredRoles
+	BlockedRoles  []string
+	MinAccountAge time.Duration
+	MinMemberAge  time.Duration
+	ClaimWindow   time.Duration   // how long winners have to claim, 0 for no claiming
+	Drop          bool            // the first Winners eligible members to click win, no draw
+	Question      string          // quiz question members must answer to enter, empty for none
+	Answers       []string        // accepted answers to Question
+	IgnoreCase    bool            // whether answers match regardless of case
+	QuizAttempts  int             // answers a member may give to Question
+	Reminders     []time.Duration // reminder offsets before EndTime, longest first
+	ReminderMsgID string          // reminder message that later reminders edit
+	Participants  []string
+	Excluded      []string
+	ChannelID     string
+	MessageID     string
+	PingRoleID    string // role pinged when the giveaway is posted
+	Winners       int
+	Status        string
+	EndedAt       time.Time
+	CaughtUp      bool // ended while the bot was offline and drawn on startup, not stored
+	Paused        bool
+	Remaining     time.Duration  // time left on the clock while paused
+	Seed          string         // secret until the giveaway ends, see fairness.go
+	LateSeed      bool           // Seed was made at draw time, no commitment was published
+	BonusRoles    map[string]int // role ID -> entries for members with that role
+	Entries       map[string]int // user ID -> entries, 1 when missing
 }
 
+// EntriesOf returns how many entries a participant has in the draw.
+func (ga *Giveaway) EntriesOf(userID string) int {
+	if n := ga.Entries[userID]; n > 1 {
+		return n
+	}
+	return 1
+}
+
+// RemoveParticipant takes a user out of the giveaway. It returns false if they
+// had not entered.
+func (ga *Giveaway) RemoveParticipant(userID string) bool {
+	idx := slices.Index(ga.Participants, userID)
+	if idx < 0 {
+		return false
+	}
+	ga.Participants = slices.Delete(ga.Participants, idx, idx+1)
+	delete(ga.Entries, userID)
+	return true
+}
+
+// TotalEntries sums the entries of all participants.
+func (ga *Giveaway) TotalEntries() int {
+	total := 0
+	for _, p := range ga.Participants {
+		total += ga.EntriesOf(p)
+	}
+	return total
+}
+
+// EntriesForRoles returns the entries a member with the given roles gets: the
+// highest bonus among their roles, or 1 without any.
+func (ga *Giveaway) EntriesForRoles(roles []string) int {
+	entries := 1
+	for _, r := range roles {
+		if n := ga.BonusRoles[r]; n > entries {
+			entries = n
+		}
+	}
+	return entries
+}
+
+// How RequiredRoles are matched against a member's roles.
+const (
+	RoleModeAny = "any"
+	RoleModeAll = "all"
+)
+
+// CheckRoles returns why a member with the given roles may not enter, or an
+// empty string if they may.
+func (ga *Giveaway) CheckRoles(roles []string) string {
+	for _, blocked := range ga.BlockedRoles {
+		if slices.Contains(roles, blocked) {
+			return fmt.Sprintf("You can't join this giveaway while you have the <@&%s> role.", blocked)
+		}
+	}
+	if len(ga.RequiredRoles) == 0 {
+		return ""
+	}
+	if ga.RoleMode == RoleModeAll {
+		for _, required := range ga.RequiredRoles {
+			if !slices.Contains(roles, required) {
+				return fmt.Sprintf("You need all of these roles to join: %s.", roleMentions(ga.RequiredRoles))
+			}
+		}
+		return ""
+	}
+	for _, required := range ga.RequiredRoles {
+		if slices.Contains(roles, required) {
+			return ""
+		}
+	}
+	if len(ga.RequiredRoles) == 1 {
+		return "You don't have the required role to join."
+	}
+	return fmt.Sprintf("You need one of these roles to join: %s.", roleMentions(ga.RequiredRoles))
+}
+
+func roleMentions(roleIDs []string) string {
+	mentions := make([]string, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		mentions = append(mentions, "<@&"+id+">")
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// roleRequirementLines describes the role requirements for the embed.
+func roleRequirementLines(ga *Giveaway) string {
+	var lines string
+	switch {
+	case len(ga.RequiredRoles) == 1:
+		lines += fmt.Sprintf("Role Required: **%s**\n", roleMentions(ga.RequiredRoles))
+	case len(ga.RequiredRoles) > 1 && ga.RoleMode == RoleModeAll:
+		lines += fmt.Sprintf("Roles Required (all of): **%s**\n", roleMentions(ga.RequiredRoles))
+	case len(ga.RequiredRoles) > 1:
+		lines += fmt.Sprintf("Roles Required (any of): **%s**\n", roleMentions(ga.RequiredRoles))
+	}
+	if len(ga.BlockedRoles) > 0 {
+		lines += fmt.Sprintf("Blocked Roles: **%s**\n", roleMentions(ga.BlockedRoles))
+	}
+	return lines
+}
+
+// Giveaway states as stored in the giveaways table.
+const (
+	StatusScheduled = "scheduled" // waiting for its start time to be posted
+	StatusStarting  = "starting"  // being posted
+	StatusActive    = "active"
+	StatusEnded     = "ended"
+	StatusCancelled = "cancelled"
+)
+
+// Win is a row of the winners table.
+type Win struct {
+	GiveawayID    string
+	GuildID       string
+	UserID        string
+	WonAt         time.Time
+	Source        string
+	MessageID     string    // announcement with the Claim button
+	ClaimDeadline time.Time // zero when the giveaway has no claim window
+	ClaimedAt     time.Time
+	Forfeited     bool
+}
+
+// How a row in the winners table was picked.
+const (
+	WinSourceDraw   = "draw"
+	WinSourceReroll = "reroll"
+	WinSourceDrop   = "drop" // claimed a slot of a drop giveaway
+)
+
 var (
 	Giveaways      = make(map[string]*Giveaway)
 	GiveawaysMutex sync.RWMutex
 )
 
 func ParseEndTime(endStr string) (time.Time, error) {
+	return ParseEndTimeFrom(endStr, time.Now())
+}
+
+// ParseEndTimeFrom is ParseEndTime with durations counted from the given time
+// instead of now, e.g. from the start of a scheduled giveaway.
+func ParseEndTimeFrom(endStr string, from time.Time) (time.Time, error) {
 	loc, err := time.LoadLocation("Etc/UTC")
 	if err != nil {
 		return time.Time{}, err
 	}
 	dur, err := time.ParseDuration(endStr)
 	if err == nil {
-		return time.Now().In(loc).Add(dur), nil
+		return from.In(loc).Add(dur), nil
 	}
 	formats := []string{
 		"2006-01-02 15:04",
@@ -56,45 +223,302 @@ func ParseEndTime(endStr string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid format")
 }
 
-func CreateGiveawayEmbed(title string, endTime time.Time, roleID string, participants int, winners int) *discordgo.MessageEmbed {
-	loc, _ := time.LoadLocation("Etc/UTC")
-	roleMention := "None"
-	if roleID != "" {
-		roleMention = "<@&" + roleID + ">"
+// ParseAge reads a minimum age such as "30d", "2w" or "12h30m". Besides the
+// units of time.ParseDuration it accepts d for days and w for weeks.
+func ParseAge(ageStr string) (time.Duration, error) {
+	ageStr = strings.TrimSpace(strings.ToLower(ageStr))
+	var total time.Duration
+	for ageStr != "" {
+		i := 0
+		for i < len(ageStr) && ageStr[i] >= '0' && ageStr[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid format")
+		}
+		n, err := strconv.Atoi(ageStr[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid format")
+		}
+		ageStr = ageStr[i:]
+		switch {
+		case strings.HasPrefix(ageStr, "w"):
+			total += time.Duration(n) * 7 * 24 * time.Hour
+			ageStr = ageStr[1:]
+		case strings.HasPrefix(ageStr, "d"):
+			total += time.Duration(n) * 24 * time.Hour
+			ageStr = ageStr[1:]
+		default:
+			j := 0
+			for j < len(ageStr) && (ageStr[j] < '0' || ageStr[j] > '9') {
+				j++
+			}
+			d, err := time.ParseDuration(strconv.Itoa(n) + ageStr[:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid format")
+			}
+			total += d
+			ageStr = ageStr[j:]
+		}
+	}
+	return total, nil
+}
+
+// FormatAge prints a duration in days and hours, e.g. "7d 12h".
+func FormatAge(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
+
+// CheckAge returns why a member may not enter yet because their account or
+// server membership is too new, including when they become eligible. It
+// returns an empty string if they may enter.
+func (ga *Giveaway) CheckAge(userID string, joinedAt time.Time) string {
+	now := time.Now()
+	if ga.MinAccountAge > 0 {
+		created, err := discordgo.SnowflakeTimestamp(userID)
+		if err == nil && now.Sub(created) < ga.MinAccountAge {
+			eligible := created.Add(ga.MinAccountAge)
+			return fmt.Sprintf("Your account must be at least %s old to join. You can enter <t:%d:R> (<t:%d:f>).",
+				FormatAge(ga.MinAccountAge), eligible.Unix(), eligible.Unix())
+		}
+	}
+	if ga.MinMemberAge > 0 && !joinedAt.IsZero() && now.Sub(joinedAt) < ga.MinMemberAge {
+		eligible := joinedAt.Add(ga.MinMemberAge)
+		return fmt.Sprintf("You must have been in this server for at least %s to join. You can enter <t:%d:R> (<t:%d:f>).",
+			FormatAge(ga.MinMemberAge), eligible.Unix(), eligible.Unix())
+	}
+	return ""
+}
+
+func ageRequirementLines(ga *Giveaway) string {
+	var lines string
+	if ga.MinAccountAge > 0 {
+		lines += fmt.Sprintf("Minimum Account Age: **%s**\n", FormatAge(ga.MinAccountAge))
 	}
-	timestamp := fmt.Sprintf("<t:%d:R>", endTime.Unix())
+	if ga.MinMemberAge > 0 {
+		lines += fmt.Sprintf("Minimum Time in Server: **%s**\n", FormatAge(ga.MinMemberAge))
+	}
+	return lines
+}
 
-	description := fmt.Sprintf(
+func CreateGiveawayEmbed(ga *Giveaway) *discordgo.MessageEmbed {
+	loc, _ := time.LoadLocation("Etc/UTC")
+	timestamp := fmt.Sprintf("<t:%d:R>", ga.EndTime.Unix())
+
+	description := prizeDescription(ga)
+	header := fmt.Sprintf(
 		"Click 🎉 button to enter!\n"+
-			"Participants: **%d**\n"+
+			"Participants: **%d**%s\n"+
 			"Winners: **%d**\n"+
+			"%s"+
 			"Ends: %s\n\n",
-		participants,
-		winners,
+		len(ga.Participants),
+		entriesSuffix(ga),
+		ga.Winners,
+		hostedByLine(ga),
 		timestamp)
+	if ga.Drop {
+		header = dropHeader(ga, timestamp)
+	}
+	description += header
 
-	if roleMention != "None" {
-		description += fmt.Sprintf("Role Required: **%s**", roleMention)
+	description += roleRequirementLines(ga)
+	description += ageRequirementLines(ga)
+	description += bonusRolesLine(ga)
+	if ga.ClaimWindow > 0 {
+		description += fmt.Sprintf("Winners must claim within **%s** or the prize is rerolled\n", FormatAge(ga.ClaimWindow))
 	}
+	description += quizLines(ga)
 
-	return &discordgo.MessageEmbed{
-		Title:       title,
+	embed := &discordgo.MessageEmbed{
+		Title:       ga.Title,
 		Description: description,
 		Color:       0x00ff00,
-		Timestamp:   endTime.In(loc).Format(time.RFC3339),
+		Timestamp:   ga.EndTime.In(loc).Format(time.RFC3339),
 		Footer:      &discordgo.MessageEmbedFooter{Text: "Ends at"},
 	}
+	setPrizeImages(embed, ga)
+	if ga.Seed != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Seed commitment (SHA-256)",
+			Value: "`" + SeedCommitment(ga.Seed) + "`",
+		})
+	}
+
+	if ga.Paused {
+		embed.Title = "[Paused] " + ga.Title
+		embed.Color = 0xffa500
+		embed.Description = prizeDescription(ga)
+		embed.Description += fmt.Sprintf(
+			"⏸️ **This giveaway is paused.** Entries are closed until it is resumed.\n"+
+				"Participants: **%d**%s\n"+
+				"Winners: **%d**\n"+
+				"%s"+
+				"Time left when resumed: **%s**\n\n",
+			len(ga.Participants),
+			entriesSuffix(ga),
+			ga.Winners,
+			hostedByLine(ga),
+			ga.Remaining.Round(time.Second))
+		embed.Description += roleRequirementLines(ga)
+		embed.Description += ageRequirementLines(ga)
+		embed.Description += bonusRolesLine(ga)
+		embed.Timestamp = ""
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: "Paused"}
+	}
+	return embed
+}
+
+func prizeDescription(ga *Giveaway) string {
+	if ga.Description == "" {
+		return ""
+	}
+	return ga.Description + "\n\n"
+}
+
+// setPrizeImages shows the prize image and thumbnail of a giveaway on an
+// embed about it.
+func setPrizeImages(embed *discordgo.MessageEmbed, ga *Giveaway) {
+	if ga.ImageURL != "" {
+		embed.Image = &discordgo.MessageEmbedImage{URL: ga.ImageURL}
+	}
+	if ga.ThumbnailURL != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: ga.ThumbnailURL}
+	}
+}
+
+func hostedByLine(ga *Giveaway) string {
+	if ga.HostID == "" {
+		return ""
+	}
+	return fmt.Sprintf("Hosted by: <@%s>\n", ga.HostID)
+}
+
+// entriesSuffix shows the total entries next to the participant count when
+// bonus entries make them differ.
+func entriesSuffix(ga *Giveaway) string {
+	if total := ga.TotalEntries(); total != len(ga.Participants) {
+		return fmt.Sprintf(" (%d entries)", total)
+	}
+	return ""
+}
+
+func bonusRolesLine(ga *Giveaway) string {
+	if len(ga.BonusRoles) == 0 {
+		return ""
+	}
+	roles := make([]string, 0, len(ga.BonusRoles))
+	for id := range ga.BonusRoles {
+		roles = append(roles, id)
+	}
+	sort.Strings(roles)
+	var parts []string
+	for _, id := range roles {
+		parts = append(parts, fmt.Sprintf("<@&%s> ×%d", id, ga.BonusRoles[id]))
+	}
+	return "Bonus Entries: " + strings.Join(parts, ", ") + "\n"
 }
 
 func UpdateGiveawayEmbed(s *discordgo.Session, ga *Giveaway) {
-	embed := CreateGiveawayEmbed(ga.Title, ga.EndTime, ga.RoleID, len(ga.Participants), ga.Winners)
+	embed := CreateGiveawayEmbed(ga)
 	_, err := s.ChannelMessageEditEmbed(ga.ChannelID, ga.MessageID, embed)
 	if err != nil {
 		log.Println("Error updating embed:", err)
 	}
 }
 
-func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
+// SeedRevealFields publishes the server seed of an ended giveaway next to its
+// commitment. Seeds made at draw time are not shown.
+func SeedRevealFields(ga *Giveaway) []*discordgo.MessageEmbedField {
+	if ga.LateSeed {
+		// Revealing a seed nobody committed to proves nothing.
+		return nil
+	}
+	return []*discordgo.MessageEmbedField{
+		{
+			Name:  "Server seed",
+			Value: "`" + ga.Seed + "`",
+		},
+		{
+			Name:  "Seed commitment (SHA-256)",
+			Value: "`" + SeedCommitment(ga.Seed) + "`",
+		},
+		{
+			Name:  "Verify",
+			Value: fmt.Sprintf("`/verify-giveaway id:%s`", ga.ID),
+		},
+	}
+}
+
+// CancelGiveaway marks the original message as cancelled, saying why, and
+// disables its buttons. No winners are drawn.
+func CancelGiveaway(s *discordgo.Session, ga *Giveaway, reason string) {
+	embed := CreateGiveawayEmbed(ga)
+	embed.Title = "[Cancelled] " + ga.Title
+	embed.Color = 0x808080
+	embed.Description = "**This giveaway has been cancelled.** " + reason
+	embed.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: "Cancelled at"}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Emoji:    &discordgo.ComponentEmoji{Name: "🎉"},
+					Style:    discordgo.PrimaryButton,
+					CustomID: "enter_giveaway",
+					Disabled: true,
+				},
+				discordgo.Button{
+					Label:    "Participants",
+					Style:    discordgo.SecondaryButton,
+					CustomID: "list_participants_1",
+					Disabled: true,
+				},
+			},
+		},
+	}
+
+	messageEdit := &discordgo.MessageEdit{
+		ID:         ga.MessageID,
+		Channel:    ga.ChannelID,
+		Embed:      embed,
+		Components: &components,
+	}
+	_, err := s.ChannelMessageEditComplex(messageEdit)
+	if err != nil {
+		log.Printf("Error updating message %s in channel %s: %v", ga.MessageID, ga.ChannelID, err)
+	}
+}
+
+func drawnLateNote(ga *Giveaway) string {
+	if !ga.CaughtUp {
+		return ""
+	}
+	return fmt.Sprintf("*Drawn late: this giveaway ended <t:%d:R> while the bot was offline.*", ga.EndTime.Unix())
+}
+
+// EndGiveaway draws the winners, announces them and disables the entry
+// button. It returns the drawn user IDs and the ID of the announcement so the
+// caller can persist them.
+func EndGiveaway(s *discordgo.Session, ga *Giveaway) ([]string, string) {
+	ga.Status = StatusEnded
+	ga.EndedAt = time.Now()
+	lateNote := drawnLateNote(ga)
+
 	// Check if message exists
 	_, err := s.ChannelMessage(ga.ChannelID, ga.MessageID)
 	if err != nil {
@@ -106,15 +530,19 @@ func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
 		GiveawaysMutex.Lock()
 		delete(Giveaways, ga.ID)
 		GiveawaysMutex.Unlock()
-		return
+		return nil, ""
 	}
 
+	var winners []string
+	var announcementID string
+
 	if len(ga.Participants) == 0 {
 		_, err := s.ChannelMessageSendComplex(ga.ChannelID,
 			&discordgo.MessageSend{
 				Embed: &discordgo.MessageEmbed{
-					Title: fmt.Sprintf("No one entered the giveaway for %s!", ga.Title),
-					Color: 0xff0000,
+					Title:       fmt.Sprintf("No one entered the giveaway for %s!", ga.Title),
+					Description: lateNote,
+					Color:       0xff0000,
 				},
 				Components: []discordgo.MessageComponent{
 					discordgo.ActionsRow{
@@ -132,7 +560,7 @@ func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
 			log.Println("Error sending message:", err)
 		}
 
-		embed := CreateGiveawayEmbed(ga.Title, ga.EndTime, ga.RoleID, len(ga.Participants), ga.Winners)
+		embed := CreateGiveawayEmbed(ga)
 		embed.Color = 0xff0000
 		embed.Description = "**No one entered the giveaway!**"
 
@@ -174,10 +602,7 @@ func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
 		if winnersCount > len(ga.Participants) {
 			winnersCount = len(ga.Participants)
 		}
-		rand.Shuffle(len(ga.Participants), func(i, j int) {
-			ga.Participants[i], ga.Participants[j] = ga.Participants[j], ga.Participants[i]
-		})
-		winners := ga.Participants[:winnersCount]
+		winners = Draw(ga.Seed, DrawRound, ga.Participants, ga.Entries, nil, winnersCount)
 		ga.Excluded = make([]string, len(winners))
 		copy(ga.Excluded, winners)
 		var winnerMentions []string
@@ -197,27 +622,39 @@ func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
 			mentionList = strings.Join(winnerMentions, ", ")
 			embed.Description = fmt.Sprintf("%s have won the giveaway for **%s**", mentionList, ga.Title)
 		}
-		components := []discordgo.MessageComponent{
-			discordgo.ActionsRow{
-				Components: []discordgo.MessageComponent{
-					discordgo.Button{
-						Label: "Original message",
-						Style: discordgo.LinkButton,
-						URL:   fmt.Sprintf("https://discord.com/channels/%v/%v/%v", ga.GuildID, ga.ChannelID, ga.MessageID),
-					},
-					discordgo.Button{
-						Label:    "Reroll",
-						Style:    discordgo.PrimaryButton,
-						CustomID: "reroll_" + ga.ID,
-						Disabled: bool(len(ga.Participants) <= ga.Winners),
-					},
-				},
-			},
+		if ga.Description != "" {
+			embed.Description += "\n\n" + ga.Description
 		}
-		_, err := s.ChannelMessageSendComplex(ga.ChannelID, &discordgo.MessageSend{
+		if lateNote != "" {
+			embed.Description += "\n\n" + lateNote
+		}
+		setPrizeImages(embed, ga)
+		embed.Fields = SeedRevealFields(ga)
+		announcement := *embed
+		var files []*discordgo.File
+		if ga.HasUploadedImage() {
+			// The giveaway message's upload can't be referenced from
+			// another message, so it is sent again.
+			if file := uploadedImage(s, ga); file != nil {
+				files = append(files, file)
+			} else {
+				announcement.Image = nil
+			}
+		}
+		deadline := ga.ClaimDeadline(ga.EndedAt)
+		if !deadline.IsZero() {
+			var wins []Win
+			for _, uid := range winners {
+				wins = append(wins, Win{UserID: uid, ClaimDeadline: deadline})
+			}
+			announcement.Fields = append([]*discordgo.MessageEmbedField{ClaimsField(wins)}, embed.Fields...)
+		}
+		components := WinnerComponents(ga, !deadline.IsZero())
+		msg, err := s.ChannelMessageSendComplex(ga.ChannelID, &discordgo.MessageSend{
 			Content:    pingText,
-			Embed:      embed,
+			Embed:      &announcement,
 			Components: components,
+			Files:      files,
 			Reference: &discordgo.MessageReference{
 				MessageID: ga.MessageID,
 				ChannelID: ga.ChannelID,
@@ -225,6 +662,8 @@ func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
 		})
 		if err != nil {
 			log.Println("Error sending winner message:", err)
+		} else {
+			announcementID = msg.ID
 		}
 
 		components = []discordgo.MessageComponent{
@@ -259,5 +698,5 @@ func EndGiveaway(s *discordgo.Session, ga *Giveaway) {
 			}
 		}
 	}
-
+	return winners, announcementID
 }
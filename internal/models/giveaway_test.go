@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		required []string
+		mode     string
+		blocked  []string
+		roles    []string
+		want     string
+	}{
+		{name: "no restrictions", roles: []string{"a"}},
+		{name: "no restrictions, no roles"},
+		{name: "single required role", required: []string{"a"}, roles: []string{"x", "a"}},
+		{name: "single required role missing", required: []string{"a"}, roles: []string{"x"},
+			want: "You don't have the required role to join."},
+		{name: "any of several", required: []string{"a", "b"}, roles: []string{"b"}},
+		{name: "any of several missing", required: []string{"a", "b"}, roles: []string{"c"},
+			want: "You need one of these roles to join: <@&a>, <@&b>."},
+		{name: "all of several", required: []string{"a", "b"}, mode: RoleModeAll, roles: []string{"b", "a"}},
+		{name: "all of several missing one", required: []string{"a", "b"}, mode: RoleModeAll, roles: []string{"a"},
+			want: "You need all of these roles to join: <@&a>, <@&b>."},
+		{name: "blocked role", blocked: []string{"x"}, roles: []string{"x"},
+			want: "You can't join this giveaway while you have the <@&x> role."},
+		{name: "blocked role wins over required", required: []string{"a"}, blocked: []string{"x"}, roles: []string{"a", "x"},
+			want: "You can't join this giveaway while you have the <@&x> role."},
+		{name: "blocked role not held", required: []string{"a"}, blocked: []string{"x"}, roles: []string{"a"}},
+	}
+	for _, tt := range tests {
+		ga := &Giveaway{RequiredRoles: tt.required, RoleMode: tt.mode, BlockedRoles: tt.blocked}
+		if got := ga.CheckRoles(tt.roles); got != tt.want {
+			t.Errorf("%s: CheckRoles = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30d", want: 30 * day},
+		{input: "2w", want: 14 * day},
+		{input: "12h30m", want: 12*time.Hour + 30*time.Minute},
+		{input: "1w2d", want: 9 * day},
+		{input: "1d12h", want: day + 12*time.Hour},
+		{input: "2d1w", want: 9 * day},
+		{input: "90m", want: 90 * time.Minute},
+		{input: " 7D ", want: 7 * day},
+		{input: "0d"},
+		{input: ""},
+		{input: "30", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "1y", wantErr: true},
+		{input: "1.5d", wantErr: true},
+		{input: "a week", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAge(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAge(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// internal/models/images.go
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Links to uploaded files expire, so an uploaded prize image is sent along
+// with the giveaway message and its embed points at it with attachment://.
+// Edits of that message keep the file.
+const attachmentScheme = "attachment://"
+
+// Largest prize image upload the bot sends again.
+const MaxImageUpload = 8 << 20
+
+var imageClient = &http.Client{Timeout: 15 * time.Second}
+
+// HasUploadedImage reports whether the prize image was uploaded with the
+// giveaway message rather than given as a link.
+func (ga *Giveaway) HasUploadedImage() bool {
+	return strings.HasPrefix(ga.ImageURL, attachmentScheme)
+}
+
+// SetImageUpload makes an uploaded image the prize image of a giveaway.
+func (ga *Giveaway) SetImageUpload(file *discordgo.File) {
+	ga.ImageUpload = file
+	ga.ImageURL = attachmentScheme + file.Name
+}
+
+// UploadName is the file name a prize image upload is sent under.
+func UploadName(filename string) string {
+	return "prize" + strings.ToLower(path.Ext(filename))
+}
+
+// DownloadImage fetches an uploaded image so it can be sent with a message.
+func DownloadImage(url string, name string) (*discordgo.File, error) {
+	resp, err := imageClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download of %s failed: %s", name, resp.Status)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("%s is not an image", name)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxImageUpload+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageUpload {
+		return nil, fmt.Errorf("%s is larger than %d MB", name, MaxImageUpload>>20)
+	}
+	return &discordgo.File{Name: name, ContentType: contentType, Reader: bytes.NewReader(data)}, nil
+}
+
+// uploadedImage downloads the prize image again from the giveaway message,
+// whose attachment links are fresh when the message is fetched. It returns
+// nil if the image is gone.
+func uploadedImage(s *discordgo.Session, ga *Giveaway) *discordgo.File {
+	msg, err := s.ChannelMessage(ga.ChannelID, ga.MessageID)
+	if err != nil {
+		log.Printf("Error fetching giveaway message %s for its image: %v", ga.MessageID, err)
+		return nil
+	}
+	name := strings.TrimPrefix(ga.ImageURL, attachmentScheme)
+	for _, attachment := range msg.Attachments {
+		if attachment.Filename != name {
+			continue
+		}
+		file, err := DownloadImage(attachment.URL, name)
+		if err != nil {
+			log.Printf("Error downloading image of giveaway %s: %v", ga.ID, err)
+			return nil
+		}
+		return file
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// internal/models/quiz.go
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Attempts a member gets at a quiz question unless the host sets another
+	// limit.
+	DefaultQuizAttempts = 3
+	MaxQuizAnswers      = 10
+)
+
+// QuizAnswer is one attempt of a member at the question of a quiz giveaway.
+type QuizAnswer struct {
+	GiveawayID string
+	GuildID    string
+	UserID     string
+	Answer     string
+	Correct    bool
+	AnsweredAt time.Time
+}
+
+// IsQuiz reports whether members have to answer a question to enter.
+func (ga *Giveaway) IsQuiz() bool {
+	return ga.Question != ""
+}
+
+// ParseQuizAnswers reads the accepted answers of a quiz, separated by |, such
+// as "Paris | paris, France".
+func ParseQuizAnswers(input string) ([]string, error) {
+	var answers []string
+	for _, part := range strings.Split(input, "|") {
+		if answer := normalizeAnswer(part); answer != "" {
+			answers = append(answers, answer)
+		}
+	}
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("expected at least one answer, separate several with |")
+	}
+	if len(answers) > MaxQuizAnswers {
+		return nil, fmt.Errorf("at most %d answers are allowed", MaxQuizAnswers)
+	}
+	return answers, nil
+}
+
+// CheckAnswer reports whether an answer matches one of the accepted answers.
+// Surrounding and repeated whitespace never matters.
+func (ga *Giveaway) CheckAnswer(answer string) bool {
+	answer = normalizeAnswer(answer)
+	for _, accepted := range ga.Answers {
+		if answer == accepted || ga.IgnoreCase && strings.EqualFold(answer, accepted) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeAnswer(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func quizLines(ga *Giveaway) string {
+	if !ga.IsQuiz() {
+		return ""
+	}
+	attempts := "1 attempt"
+	if ga.QuizAttempts != 1 {
+		attempts = fmt.Sprintf("%d attempts", ga.QuizAttempts)
+	}
+	return fmt.Sprintf("❓ **Quiz:** %s\nAnswer correctly to enter (%s per member)\n", ga.Question, attempts)
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseQuizAnswers(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{input: "Paris", want: []string{"Paris"}},
+		{input: "Paris | paris, France", want: []string{"Paris", "paris, France"}},
+		{input: "  New   York  |NYC", want: []string{"New York", "NYC"}},
+		{input: "a||b|", want: []string{"a", "b"}},
+		{input: strings.Repeat("x|", MaxQuizAnswers), want: slices.Repeat([]string{"x"}, MaxQuizAnswers)},
+		{input: "", wantErr: true},
+		{input: " | | ", wantErr: true},
+		{input: strings.Repeat("x|", MaxQuizAnswers+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuizAnswers(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseQuizAnswers(%q) = %q, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseQuizAnswers(%q): %v", tt.input, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseQuizAnswers(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCheckAnswer(t *testing.T) {
+	answers := []string{"Paris", "New York"}
+	tests := []struct {
+		answer     string
+		ignoreCase bool
+		want       bool
+	}{
+		{answer: "Paris", want: true},
+		{answer: "  New   York ", want: true},
+		{answer: "paris", want: false},
+		{answer: "paris", ignoreCase: true, want: true},
+		{answer: "NEW YORK", ignoreCase: true, want: true},
+		{answer: "Pariss", ignoreCase: true, want: false},
+		{answer: "", ignoreCase: true, want: false},
+	}
+	for _, tt := range tests {
+		ga := &Giveaway{Question: "Which city?", Answers: answers, IgnoreCase: tt.ignoreCase}
+		if got := ga.CheckAnswer(tt.answer); got != tt.want {
+			t.Errorf("CheckAnswer(%q) with ignore case %v = %v, want %v", tt.answer, tt.ignoreCase, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// internal/models/reminders.go
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Most reminders a giveaway can have.
+const MaxReminders = 5
+
+// ParseReminders reads reminder offsets before the end of a giveaway, such as
+// "1h, 10m" or "1d 1h". "none" turns reminders off. The offsets are returned
+// longest first.
+func ParseReminders(input string) ([]time.Duration, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	if input == "none" || input == "off" {
+		return []time.Duration{}, nil
+	}
+	var offsets []time.Duration
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		d, err := ParseAge(part)
+		if err != nil || d < time.Minute {
+			return nil, fmt.Errorf("%q is not an offset like 1h or 10m", part)
+		}
+		if !slices.Contains(offsets, d) {
+			offsets = append(offsets, d)
+		}
+	}
+	if len(offsets) == 0 {
+		return nil, fmt.Errorf("expected offsets like 1h, 10m or none")
+	}
+	if len(offsets) > MaxReminders {
+		return nil, fmt.Errorf("at most %d reminders are allowed", MaxReminders)
+	}
+	slices.SortFunc(offsets, func(a, b time.Duration) int { return int(b - a) })
+	return offsets, nil
+}
+
+// FormatReminders prints reminder offsets the way ParseReminders reads them.
+func FormatReminders(offsets []time.Duration) string {
+	if len(offsets) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(offsets))
+	for k, d := range offsets {
+		parts[k] = strings.ReplaceAll(FormatAge(d), " ", "")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseReminders(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []time.Duration
+		wantErr bool
+	}{
+		{input: "1h", want: []time.Duration{time.Hour}},
+		{input: "10m, 1h", want: []time.Duration{time.Hour, 10 * time.Minute}},
+		{input: "1d 1h", want: []time.Duration{24 * time.Hour, time.Hour}},
+		{input: "1d1h,10m", want: []time.Duration{25 * time.Hour, 10 * time.Minute}},
+		{input: "1h, 60m, 1h", want: []time.Duration{time.Hour}},
+		{input: "1w 1d 1h 10m 1m", want: []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, 10 * time.Minute, time.Minute}},
+		{input: " None ", want: []time.Duration{}},
+		{input: "off", want: []time.Duration{}},
+		{input: "", wantErr: true},
+		{input: " , ", wantErr: true},
+		{input: "30s", wantErr: true},
+		{input: "0m", wantErr: true},
+		{input: "1h, soon", wantErr: true},
+		{input: "1w 1d 1h 10m 5m 1m", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseReminders(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseReminders(%q) = %v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseReminders(%q): %v", tt.input, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) || got == nil {
+			t.Errorf("ParseReminders(%q) = %#v, want %#v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFormatReminders(t *testing.T) {
+	tests := []struct {
+		offsets []time.Duration
+		want    string
+	}{
+		{nil, "none"},
+		{[]time.Duration{time.Hour, 10 * time.Minute}, "1h, 10m"},
+		{[]time.Duration{25*time.Hour + 30*time.Minute}, "1d1h30m"},
+	}
+	for _, tt := range tests {
+		got := FormatReminders(tt.offsets)
+		if got != tt.want {
+			t.Errorf("FormatReminders(%v) = %q, want %q", tt.offsets, got, tt.want)
+		}
+		if parsed, err := ParseReminders(got); err != nil || !slices.Equal(parsed, tt.offsets) {
+			t.Errorf("ParseReminders(%q) = %v, %v; want %v back", got, parsed, err, tt.offsets)
+		}
+	}
+}
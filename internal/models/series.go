@@ -0,0 +1,189 @@
+// internal/models/series.go
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Series is a recurring giveaway definition. Every occurrence is posted as a
+// regular giveaway that keeps the series ID.
+type Series struct {
+	ID            string
+	GuildID       string
+	ChannelID     string
+	HostID        string
+	Title         string // may contain {n} and {date}, see SeriesTitle
+	Schedule      string // "daily", "weekly" or a cron expression
+	Duration      time.Duration
+	Winners       int
+	RequiredRoles []string
+	NextRun       time.Time
+	Occurrences   int
+	Active        bool
+	CreatedAt     time.Time
+}
+
+// OccurrenceTitle fills in the title template for the nth occurrence: {n} is
+// replaced by the occurrence number and {date} by the start date.
+func (sr *Series) OccurrenceTitle(n int, start time.Time) string {
+	title := strings.ReplaceAll(sr.Title, "{n}", strconv.Itoa(n))
+	return strings.ReplaceAll(title, "{date}", start.UTC().Format("2006-01-02"))
+}
+
+// Recurrence tells when the next occurrence of a series is due.
+type Recurrence interface {
+	// Next returns the first run strictly after the given time.
+	Next(after time.Time) time.Time
+}
+
+type interval time.Duration
+
+func (d interval) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(d))
+}
+
+// ParseRecurrence reads a series schedule: "daily", "weekly" or a five field
+// cron expression "minute hour day-of-month month day-of-week" in UTC.
+// Daily and weekly series repeat at the time of their first occurrence.
+func ParseRecurrence(spec string) (Recurrence, error) {
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case "daily":
+		return interval(24 * time.Hour), nil
+	case "weekly":
+		return interval(7 * 24 * time.Hour), nil
+	}
+	c, err := parseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func parseCron(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("use daily, weekly or a cron expression with 5 fields")
+	}
+	var sets [5]uint64
+	for k, f := range fields {
+		set, err := parseCronField(f, cronFields[k].min, cronFields[k].max)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cronFields[k].name, err)
+		}
+		sets[k] = set
+	}
+	// Sunday can be written as 0 or 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &cronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField reads a comma separated list of *, n, a-b and step values
+// such as */15 or 1-5/2 into a bit set.
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+		lo, hi := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			lo, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				hi, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	// Like cron, a day matches either field when both are restricted.
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+func (c *cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Expressions like "0 0 31 2 *" never match; give up after five years.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<t.Hour()) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// 2024-01-01 was a Monday.
+	tests := []struct {
+		spec  string
+		after time.Time
+		want  time.Time
+	}{
+		{"daily", utc(2024, 1, 1, 10, 0), utc(2024, 1, 2, 10, 0)},
+		{"Weekly", utc(2024, 1, 1, 10, 0), utc(2024, 1, 8, 10, 0)},
+
+		// Runs are strictly after the given time.
+		{"0 10 * * *", utc(2024, 1, 1, 10, 0), utc(2024, 1, 2, 10, 0)},
+		{"0 10 * * *", utc(2024, 1, 1, 9, 59).Add(30 * time.Second), utc(2024, 1, 1, 10, 0)},
+
+		// Steps, ranges and lists.
+		{"*/15 * * * *", utc(2024, 1, 1, 10, 7), utc(2024, 1, 1, 10, 15)},
+		{"*/15 * * * *", utc(2024, 1, 1, 10, 45), utc(2024, 1, 1, 11, 0)},
+		{"0 9-17/4 * * *", utc(2024, 1, 1, 10, 0), utc(2024, 1, 1, 13, 0)},
+		{"0 9-17/4 * * *", utc(2024, 1, 1, 17, 0), utc(2024, 1, 2, 9, 0)},
+		{"5/20 * * * *", utc(2024, 1, 1, 10, 26), utc(2024, 1, 1, 10, 45)},
+		{"0,30 8 * * *", utc(2024, 1, 1, 8, 0), utc(2024, 1, 1, 8, 30)},
+
+		// Day of week, with Sunday as 0 or 7.
+		{"30 18 * * 5", utc(2024, 1, 1, 0, 0), utc(2024, 1, 5, 18, 30)},
+		{"0 0 * * 0", utc(2024, 1, 1, 0, 0), utc(2024, 1, 7, 0, 0)},
+		{"0 0 * * 7", utc(2024, 1, 1, 0, 0), utc(2024, 1, 7, 0, 0)},
+		{"0 0 * * 1-5", utc(2024, 1, 5, 12, 0), utc(2024, 1, 8, 0, 0)},
+
+		// Day of month, and either day field when both are restricted.
+		{"0 12 15 * *", utc(2024, 1, 20, 0, 0), utc(2024, 2, 15, 12, 0)},
+		{"0 0 13 * 5", utc(2024, 1, 1, 0, 0), utc(2024, 1, 5, 0, 0)},
+		{"0 0 13 * 5", utc(2024, 1, 12, 0, 0), utc(2024, 1, 13, 0, 0)},
+
+		// Month and year rollover.
+		{"0 0 31 * *", utc(2024, 1, 31, 0, 0), utc(2024, 3, 31, 0, 0)},
+		{"0 0 1 1 *", utc(2024, 6, 1, 0, 0), utc(2025, 1, 1, 0, 0)},
+		{"0 0 * 3 *", utc(2024, 3, 31, 23, 59), utc(2025, 3, 1, 0, 0)},
+		{"59 23 31 12 *", utc(2024, 1, 1, 0, 0), utc(2024, 12, 31, 23, 59)},
+		{"0 0 29 2 *", utc(2024, 3, 1, 0, 0), utc(2028, 2, 29, 0, 0)},
+
+		// Times are read in UTC.
+		{"0 10 * * *", time.Date(2024, 1, 1, 11, 0, 0, 0, time.FixedZone("CEST", 2*3600)), utc(2024, 1, 1, 10, 0)},
+
+		// Never matches.
+		{"0 0 31 2 *", utc(2024, 1, 1, 0, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		rec, err := ParseRecurrence(tt.spec)
+		if err != nil {
+			t.Errorf("ParseRecurrence(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := rec.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%q after %v = %v, want %v", tt.spec, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestParseRecurrenceInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"hourly",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"5-1 * * * *",
+		"1-a * * * *",
+		"-1 * * * *",
+		"1,,2 * * * *",
+	} {
+		if rec, err := ParseRecurrence(spec); err == nil || rec != nil {
+			t.Errorf("ParseRecurrence(%q) = %v, %v; want an error", spec, rec, err)
+		}
+	}
+}
+
+func TestOccurrenceTitle(t *testing.T) {
+	sr := &Series{Title: "Nitro #{n} ({date})"}
+	if got, want := sr.OccurrenceTitle(3, utc(2024, 1, 5, 18, 30)), "Nitro #3 (2024-01-05)"; got != want {
+		t.Errorf("OccurrenceTitle = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+// internal/models/template.go
+package models
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Template is a saved set of /create-giveaway options of a guild.
+type Template struct {
+	GuildID   string
+	Name      string
+	Options   []TemplateOption
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// TemplateOption is one saved command option. Value holds what Discord sends
+// for the option type: a string, a float64 for integers or a bool.
+type TemplateOption struct {
+	Name  string                                 `json:"name"`
+	Type  discordgo.ApplicationCommandOptionType `json:"type"`
+	Value any                                    `json:"value"`
+}
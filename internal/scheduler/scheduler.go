@@ -0,0 +1,229 @@
+// internal/scheduler/scheduler.go
+package scheduler
+
+import (
+	"container/heap"
+	"log"
+	"sync"
+	"time"
+)
+
+// Kind says what a scheduled event does to its giveaway.
+type Kind string
+
+const (
+	KindStart Kind = "start"
+	KindEnd   Kind = "end"
+	KindClaim Kind = "claim" // Tag is the winner's user ID
+	// KindReminder announces that a giveaway ends soon. Its tag is the offset
+	// before the end.
+	KindReminder Kind = "reminder"
+	// KindSeries posts the next occurrence of a recurring giveaway. Its key
+	// holds the series ID in place of a giveaway ID.
+	KindSeries Kind = "series"
+)
+
+// Key identifies a scheduled event. Scheduling an event under a key that is
+// already pending replaces it.
+type Key struct {
+	GiveawayID string
+	Kind       Kind
+	Tag        string // tells apart several events of the same kind
+}
+
+type item struct {
+	key   Key
+	at    time.Time
+	fn    func()
+	index int
+}
+
+type eventHeap []*item
+
+func (h eventHeap) Len() int           { return len(h) }
+func (h eventHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h eventHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *eventHeap) Push(x any) {
+	it := x.(*item)
+	it.index = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *eventHeap) Pop() any {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	it.index = -1
+	*h = old[:n-1]
+	return it
+}
+
+// Scheduler runs timed events one at a time from a single goroutine, in the
+// order they are due. Events that are already due when scheduled run right
+// away.
+type Scheduler struct {
+	clock Clock
+
+	mu    sync.Mutex
+	queue eventHeap
+	byKey map[Key]*item
+
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func New(clock Clock) *Scheduler {
+	return &Scheduler{
+		clock: clock,
+		byKey: make(map[Key]*item),
+		wake:  make(chan struct{}, 1),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Start runs the event loop in the background until Stop is called.
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Stop stops the event loop and waits for a running event to finish. Events
+// that have not run yet are dropped; their state lives in the database and is
+// scheduled again on the next start.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+// Schedule runs fn at the given time, replacing any pending event with the
+// same key.
+func (s *Scheduler) Schedule(key Key, at time.Time, fn func()) {
+	s.mu.Lock()
+	if it, ok := s.byKey[key]; ok {
+		it.at = at
+		it.fn = fn
+		heap.Fix(&s.queue, it.index)
+	} else {
+		it := &item{key: key, at: at, fn: fn}
+		heap.Push(&s.queue, it)
+		s.byKey[key] = it
+	}
+	s.mu.Unlock()
+	s.poke()
+}
+
+// Reschedule moves a pending event to a new time. It returns false if there
+// is no such event, e.g. because it already ran.
+func (s *Scheduler) Reschedule(key Key, at time.Time) bool {
+	s.mu.Lock()
+	it, ok := s.byKey[key]
+	if ok {
+		it.at = at
+		heap.Fix(&s.queue, it.index)
+	}
+	s.mu.Unlock()
+	if ok {
+		s.poke()
+	}
+	return ok
+}
+
+// Cancel removes a pending event. It returns false if there is no such event.
+func (s *Scheduler) Cancel(key Key) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.byKey[key]
+	if !ok {
+		return false
+	}
+	heap.Remove(&s.queue, it.index)
+	delete(s.byKey, key)
+	return true
+}
+
+// CancelGiveaway removes every pending event of a giveaway.
+func (s *Scheduler) CancelGiveaway(giveawayID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, it := range s.byKey {
+		if key.GiveawayID != giveawayID {
+			continue
+		}
+		heap.Remove(&s.queue, it.index)
+		delete(s.byKey, key)
+	}
+}
+
+// When returns the time a pending event is due.
+func (s *Scheduler) When(key Key) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.byKey[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	return it.at, true
+}
+
+func (s *Scheduler) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		s.mu.Lock()
+		var due *item
+		var wait <-chan time.Time
+		if len(s.queue) > 0 {
+			d := s.queue[0].at.Sub(s.clock.Now())
+			if d <= 0 {
+				due = heap.Pop(&s.queue).(*item)
+				delete(s.byKey, due.key)
+			} else {
+				wait = s.clock.After(d)
+			}
+		}
+		s.mu.Unlock()
+
+		if due != nil {
+			s.runEvent(due)
+			continue
+		}
+
+		select {
+		case <-wait:
+		case <-s.wake:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Scheduler) runEvent(it *item) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Scheduled %s event for giveaway %s panicked: %v", it.key.Kind, it.key.GiveawayID, r)
+		}
+	}()
+	it.fn()
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestScheduler(t *testing.T) (*Scheduler, *FakeClock) {
+	t.Helper()
+	clock := NewFakeClock(epoch)
+	s := New(clock)
+	s.Start()
+	t.Cleanup(s.Stop)
+	return s, clock
+}
+
+func endKey(giveawayID string) Key {
+	return Key{GiveawayID: giveawayID, Kind: KindEnd}
+}
+
+// record returns an event that sends name on ch when it runs.
+func record(ch chan<- string, name string) func() {
+	return func() { ch <- name }
+}
+
+func receive(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case name := <-ch:
+		return name
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+		return ""
+	}
+}
+
+// expectRuns checks that exactly the given events run, in order, by the time
+// the scheduler is stopped.
+func expectRuns(t *testing.T, s *Scheduler, ch chan string, want ...string) {
+	t.Helper()
+	for _, name := range want {
+		if got := receive(t, ch); got != name {
+			t.Fatalf("got event %q, want %q", got, name)
+		}
+	}
+	s.Stop()
+	select {
+	case name := <-ch:
+		t.Fatalf("unexpected event %q", name)
+	default:
+	}
+}
+
+func TestEventsRunInOrder(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	ch := make(chan string, 3)
+	s.Schedule(endKey("c"), epoch.Add(3*time.Second), record(ch, "c"))
+	s.Schedule(endKey("a"), epoch.Add(time.Second), record(ch, "a"))
+	s.Schedule(endKey("b"), epoch.Add(2*time.Second), record(ch, "b"))
+
+	clock.Advance(5 * time.Second)
+	expectRuns(t, s, ch, "a", "b", "c")
+}
+
+func TestEventsWaitForTheirTime(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	ch := make(chan string, 2)
+	s.Schedule(endKey("a"), epoch.Add(time.Minute), record(ch, "a"))
+	s.Schedule(endKey("b"), epoch.Add(time.Hour), record(ch, "b"))
+
+	clock.Advance(time.Minute)
+	if got := receive(t, ch); got != "a" {
+		t.Fatalf("got event %q, want a", got)
+	}
+	if _, ok := s.When(endKey("b")); !ok {
+		t.Fatal("b ran before its time")
+	}
+	expectRuns(t, s, ch)
+}
+
+func TestDueEventsRunRightAway(t *testing.T) {
+	s, _ := newTestScheduler(t)
+	ch := make(chan string, 2)
+	s.Schedule(endKey("past"), epoch.Add(-time.Hour), record(ch, "past"))
+	s.Schedule(endKey("now"), epoch, record(ch, "now"))
+
+	expectRuns(t, s, ch, "past", "now")
+}
+
+func TestScheduleReplacesPendingEvent(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	ch := make(chan string, 2)
+	s.Schedule(endKey("a"), epoch.Add(time.Second), record(ch, "first"))
+	s.Schedule(endKey("a"), epoch.Add(2*time.Second), record(ch, "second"))
+
+	clock.Advance(5 * time.Second)
+	expectRuns(t, s, ch, "second")
+}
+
+func TestReschedule(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	ch := make(chan string, 2)
+	s.Schedule(endKey("a"), epoch.Add(time.Second), record(ch, "a"))
+	s.Schedule(endKey("b"), epoch.Add(2*time.Second), record(ch, "b"))
+
+	if !s.Reschedule(endKey("a"), epoch.Add(3*time.Second)) {
+		t.Fatal("Reschedule of a pending event returned false")
+	}
+	if s.Reschedule(endKey("missing"), epoch) {
+		t.Fatal("Reschedule of an unknown event returned true")
+	}
+	if at, _ := s.When(endKey("a")); !at.Equal(epoch.Add(3 * time.Second)) {
+		t.Fatalf("When = %v after Reschedule", at)
+	}
+
+	clock.Advance(5 * time.Second)
+	expectRuns(t, s, ch, "b", "a")
+}
+
+func TestCancel(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	ch := make(chan string, 2)
+	s.Schedule(endKey("a"), epoch.Add(time.Second), record(ch, "a"))
+	s.Schedule(endKey("b"), epoch.Add(2*time.Second), record(ch, "b"))
+
+	if !s.Cancel(endKey("a")) {
+		t.Fatal("Cancel of a pending event returned false")
+	}
+	if s.Cancel(endKey("a")) {
+		t.Fatal("second Cancel returned true")
+	}
+
+	clock.Advance(5 * time.Second)
+	expectRuns(t, s, ch, "b")
+}
+
+func TestCancelGiveaway(t *testing.T) {
+	s, clock := newTestScheduler(t)
+	ch := make(chan string, 3)
+	reminder := Key{GiveawayID: "g1", Kind: KindReminder, Tag: "1h0m0s"}
+	s.Schedule(endKey("g1"), epoch.Add(2*time.Second), record(ch, "g1 end"))
+	s.Schedule(reminder, epoch.Add(time.Second), record(ch, "g1 reminder"))
+	s.Schedule(endKey("g2"), epoch.Add(3*time.Second), record(ch, "g2 end"))
+
+	s.CancelGiveaway("g1")
+	if _, ok := s.When(reminder); ok {
+		t.Fatal("reminder of g1 is still pending")
+	}
+
+	clock.Advance(5 * time.Second)
+	expectRuns(t, s, ch, "g2 end")
+}
+
+func TestStopWaitsForRunningEvent(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	s := New(clock)
+	s.Start()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	finished := false
+	s.Schedule(endKey("slow"), epoch, func() {
+		close(started)
+		<-release
+		finished = true
+	})
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while an event was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return after the event finished")
+	}
+	if !finished {
+		t.Fatal("Stop returned before the event finished")
+	}
+}
+
+func TestPanickingEventDoesNotStopScheduler(t *testing.T) {
+	s, _ := newTestScheduler(t)
+	ch := make(chan string, 1)
+	s.Schedule(endKey("a"), epoch, func() { panic("boom") })
+	s.Schedule(endKey("b"), epoch, record(ch, "b"))
+
+	expectRuns(t, s, ch, "b")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/Cylis-Dragneel/giveaway-bot/internal/bot"
 	"github.com/Cylis-Dragneel/giveaway-bot/internal/db"
 	"github.com/Cylis-Dragneel/giveaway-bot/internal/models"
+	"github.com/Cylis-Dragneel/giveaway-bot/internal/scheduler"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -31,6 +32,16 @@ func main() {
 	}
 	log.Println("Successfully embedded schema.sql, size:", len(schemaContent), "bytes")
 
+	// Giveaways that ended longer ago than this while the bot was offline are
+	// closed without a draw.
+	catchUpWindow := 72 * time.Hour
+	if v := os.Getenv("CATCHUP_WINDOW"); v != "" {
+		catchUpWindow, err = time.ParseDuration(v)
+		if err != nil {
+			log.Fatal("Invalid CATCHUP_WINDOW: ", err)
+		}
+	}
+
 	dbPath := "giveaway.db"
 	if _, err := os.ReadFile(dbPath); err != nil {
 		log.Printf("File doesn't exist, creating...")
@@ -54,23 +65,46 @@ func main() {
 
 	bot.SetSession(dg) // Set global session for endGiveaway access
 
-	// Load active giveaways and set timers
+	sched := scheduler.New(scheduler.RealClock{})
+	bot.SetScheduler(sched)
+
+	// Load active giveaways and schedule their end
 	giveaways, err := db.LoadGiveaways()
 	if err != nil {
 		log.Fatal("Error loading giveaways: ", err)
 	}
+	var expired []*models.Giveaway
 	for _, ga := range giveaways {
-		if time.Now().After(ga.EndTime) {
-			db.DeleteGiveaway(ga.ID, ga.GuildID)
+		if ga.Paused {
+			// Paused giveaways are scheduled again on /resume-giveaway.
+			models.Giveaways[ga.ID] = ga
 			continue
 		}
-		duration := time.Until(ga.EndTime)
-		ga.Timer = time.AfterFunc(duration, func() {
-			models.EndGiveaway(dg, ga)
-			db.DeleteGiveaway(ga.ID, ga.GuildID)
-		})
+		if overdue := time.Since(ga.EndTime); overdue > catchUpWindow {
+			log.Printf("Giveaway %s ended %s ago, outside the catch-up window; closing without a draw", ga.ID, overdue.Round(time.Second))
+			expired = append(expired, ga)
+			continue
+		}
+		// Overdue giveaways are due right away and get drawn once the
+		// scheduler starts.
+		ga.CaughtUp = time.Now().After(ga.EndTime)
+		bot.ScheduleEnd(ga)
 		models.Giveaways[ga.ID] = ga
 	}
+	bot.ScheduleClaimDeadlines()
+
+	scheduled, err := db.LoadScheduledGiveaways()
+	if err != nil {
+		log.Fatal("Error loading scheduled giveaways: ", err)
+	}
+	for _, ga := range scheduled {
+		bot.ScheduleStart(ga)
+	}
+	interrupted, err := db.LoadInterruptedStarts()
+	if err != nil {
+		log.Fatal("Error loading scheduled giveaways: ", err)
+	}
+	bot.ScheduleAllSeries()
 
 	dg.AddHandler(bot.Ready)
 	dg.AddHandler(bot.InteractionCreate)
@@ -82,6 +116,16 @@ func main() {
 		log.Fatal("Error opening connection: ", err)
 	}
 
+	for _, ga := range expired {
+		bot.CloseExpiredGiveaway(ga)
+	}
+	for _, ga := range interrupted {
+		bot.CancelInterruptedStart(ga)
+	}
+
+	// Start firing events only once the session can talk to Discord.
+	sched.Start()
+
 	// Register slash commands globally
 	commands := bot.GetCommands()
 	for _, cmd := range commands {
@@ -96,6 +140,10 @@ func main() {
 	signal.Notify(sc, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	<-sc
 
+	// Let a draw in progress finish before the session and database go away.
+	log.Println("Shutting down scheduler...")
+	sched.Stop()
+
 	dg.Close()
 
 }
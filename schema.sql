@@ -7,6 +7,33 @@ CREATE TABLE IF NOT EXISTS giveaways (
     channel_id TEXT,
     message_id TEXT,
     winners INTEGER DEFAULT 1,
+    status TEXT DEFAULT 'active',
+    ended_at INTEGER DEFAULT 0,
+    paused INTEGER DEFAULT 0,
+    remaining INTEGER DEFAULT 0,
+    seed TEXT DEFAULT '',
+    bonus_roles TEXT DEFAULT '',
+    required_roles TEXT DEFAULT '',
+    role_mode TEXT DEFAULT 'any',
+    blocked_roles TEXT DEFAULT '',
+    min_account_age INTEGER DEFAULT 0,
+    min_member_age INTEGER DEFAULT 0,
+    claim_window INTEGER DEFAULT 0,
+    host_id TEXT DEFAULT '',
+    description TEXT DEFAULT '',
+    image_url TEXT DEFAULT '',
+    thumbnail_url TEXT DEFAULT '',
+    start_time INTEGER DEFAULT 0,
+    series_id TEXT DEFAULT '',
+    ping_role TEXT DEFAULT '',
+    reminders TEXT DEFAULT '',
+    reminder_message_id TEXT DEFAULT '',
+    drop_mode INTEGER DEFAULT 0,
+    quiz_question TEXT DEFAULT '',
+    quiz_answers TEXT DEFAULT '',
+    quiz_ignore_case INTEGER DEFAULT 0,
+    quiz_attempts INTEGER DEFAULT 0,
+    late_seed INTEGER DEFAULT 0,
     PRIMARY KEY (id, guild_id)
 );
 
@@ -14,5 +41,86 @@ CREATE TABLE IF NOT EXISTS participants (
     giveaway_id TEXT,
     guild_id TEXT,
     user_id TEXT,
+    entries INTEGER DEFAULT 1,
     PRIMARY KEY (giveaway_id, guild_id, user_id)
 );
+
+CREATE TABLE IF NOT EXISTS removed_participants (
+    giveaway_id TEXT,
+    guild_id TEXT,
+    user_id TEXT,
+    removed_by TEXT,
+    removed_at INTEGER,
+    PRIMARY KEY (giveaway_id, guild_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS winners (
+    giveaway_id TEXT,
+    guild_id TEXT,
+    user_id TEXT,
+    won_at INTEGER,
+    source TEXT DEFAULT 'draw',
+    message_id TEXT DEFAULT '',
+    claim_deadline INTEGER DEFAULT 0,
+    claimed_at INTEGER DEFAULT 0,
+    forfeited INTEGER DEFAULT 0,
+    PRIMARY KEY (giveaway_id, guild_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS guild_settings (
+    guild_id TEXT PRIMARY KEY,
+    manager_roles TEXT DEFAULT '',
+    bonus_roles TEXT DEFAULT '',
+    reminders TEXT DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS blacklist (
+    guild_id TEXT,
+    user_id TEXT,
+    reason TEXT DEFAULT '',
+    added_by TEXT,
+    added_at INTEGER,
+    expires_at INTEGER DEFAULT 0,
+    PRIMARY KEY (guild_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS giveaway_series (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT,
+    channel_id TEXT,
+    host_id TEXT,
+    title TEXT,
+    schedule TEXT,
+    duration INTEGER,
+    winners INTEGER DEFAULT 1,
+    required_roles TEXT DEFAULT '',
+    next_run INTEGER,
+    occurrences INTEGER DEFAULT 0,
+    active INTEGER DEFAULT 1,
+    created_at INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS giveaway_templates (
+    guild_id TEXT,
+    name TEXT,
+    options TEXT,
+    created_by TEXT,
+    created_at INTEGER,
+    PRIMARY KEY (guild_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS reminder_optins (
+    giveaway_id TEXT,
+    guild_id TEXT,
+    user_id TEXT,
+    PRIMARY KEY (giveaway_id, guild_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS quiz_answers (
+    giveaway_id TEXT,
+    guild_id TEXT,
+    user_id TEXT,
+    answer TEXT,
+    correct INTEGER,
+    answered_at INTEGER
+);